@@ -1,20 +1,31 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"math/rand"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/akamensky/argparse"
 	"github.com/gdamore/tcell"
 	"github.com/gdamore/tcell/encoding"
 	runewidth "github.com/mattn/go-runewidth"
+	"github.com/unathi-skosana/gothello/pkg/book"
 	"github.com/unathi-skosana/gothello/pkg/gomcts"
 	"github.com/unathi-skosana/gothello/pkg/othello"
+	tuning "github.com/unathi-skosana/gothello/pkg/tune"
 )
 
 const depth = 1000
+const transpositions = 1 << 16
+
+// depth of the alpha-beta search of each level without a think time
+var alphaBetaDepths = map[string]int{"easy": 2, "medium": 4, "hard": 6}
+
 const BOARD_SIZE = othello.BOARD_WIDTH
 const board_row_top = "+---+---+---+---+---+---+---+---+"
 
@@ -34,14 +45,58 @@ const (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "perft" {
+		perft(os.Args[1:])
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "book" {
+		buildBook(os.Args[1:])
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "tune" {
+		tune(os.Args[1:])
+		return
+	}
+
 	i, j := 4, 5
 	s, e := tcell.NewScreen()
 
-	eval, player, level := parsArgs()
+	cfg := parsArgs()
+	player := cfg.player
+
+	// opening book, nil unless one is given
+	openings, err := loadBook(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+	bookRng := rand.New(rand.NewSource(cfg.seed))
 
 	// BLUE always goes first.
-	var gs gomcts.GameState = othello.New(othello.BLUE)
-	clock = newClock()
+	session := othello.NewSession(othello.New(othello.BLUE))
+	// the AI searches on the faster bitboard representation
+	searcher := gomcts.NewSearcher(othello.NewBitboard(othello.BLUE), cfg.eval, searchOptions(cfg))
+	// lines describing the AI's last move
+	var report []string
+
+	moveStart := time.Now()
+	status := ""
+
+	// plays an action, keeping the AI's tree in step with the game
+	var play = func(action gomcts.Action) {
+		session.Play(action.(othello.OthelloBoardGameAction), time.Since(moveStart))
+		moveStart = time.Now()
+		searcher.Advance(action)
+	}
+
+	// restarts the AI's tree after moves were undone, redone or loaded
+	var resume = func() {
+		searcher.Reset(session.State().Bitboard())
+		moveStart = time.Now()
+		report = nil
+	}
 
 	if e != nil {
 		fmt.Fprintf(os.Stderr, "%v\n", e)
@@ -57,22 +112,55 @@ func main() {
 
 	quit := make(chan struct{})
 
+	// guards the state drawn by refresh, which the clock redraws from its
+	// own goroutine: session, report, status, the cursor and the clock
+	var mu sync.Mutex
+
 	var refresh = func() {
+		mu.Lock()
+		defer mu.Unlock()
 		s.Clear()
-		printGame(s, gs.(othello.OthelloGameState), level, gs.NextToMove() == player, i, j)
+		gs := session.State()
+		printGame(s, gs, session.Actions(), cfg.level, gs.NextToMove() == player, i, j, report, status)
 	}
 
-	clock.TickFunc = refresh
+	mu.Lock()
+	clock = newClock(refresh)
+	mu.Unlock()
 
 	go func() {
 		for {
-			// blocking
-			if gs.NextToMove() != player {
-				action := gomcts.MonteCarloTreeSearch(gs, eval, depth)
-				gs = action.ApplyTo(gs)
+			mu.Lock()
+			gs := session.State()
+			mu.Unlock()
+
+			// blocking, the AI thinks without holding the lock so the
+			// clock keeps ticking
+			if gs.NextToMove() != player && !gs.IsGameEnded() {
+				var action gomcts.Action
+				var lines []string
+				move, inBook := chooseBookMove(openings, gs, bookRng)
+				switch {
+				case inBook:
+					action, lines = move, describeBookMove(openings, gs, move)
+				case gs.Empties() <= cfg.endgame:
+					action, lines = solveEndgame(gs)
+				case cfg.algorithm == "alphabeta":
+					action, lines = alphaBeta(gs, cfg)
+				default:
+					analysis := searcher.Analyze(context.Background())
+					action, lines = analysis.Action, describeSearch(analysis)
+				}
+				mu.Lock()
+				report = lines
+				play(action)
+				mu.Unlock()
 				refresh()
 			}
 			ev := s.PollEvent()
+			mu.Lock()
+			gs = session.State()
+			status = ""
 			switch ev := ev.(type) {
 			case *tcell.EventKey:
 				switch ev.Key() {
@@ -89,11 +177,9 @@ func main() {
 						actions := gs.GetLegalActions()
 						for k := 0; k < len(actions); k++ {
 							action := actions[k].(othello.OthelloBoardGameAction)
-							move := action.GetMove()
-							y := move/10 - 1
-							x := move%10 - 1
-							if x == i && y == j {
-								gs = action.ApplyTo(gs)
+							x, y := othello.Coordinates(action.GetMove())
+							if action.IsPass() || x == i && y == j {
+								play(action)
 							}
 						}
 					}
@@ -106,11 +192,9 @@ func main() {
 							actions := gs.GetLegalActions()
 							for k := 0; k < len(actions); k++ {
 								action := actions[k].(othello.OthelloBoardGameAction)
-								move := action.GetMove()
-								y := move/10 - 1
-								x := move%10 - 1
-								if x == i && y == j {
-									gs = action.ApplyTo(gs)
+								x, y := othello.Coordinates(action.GetMove())
+								if action.IsPass() || x == i && y == j {
+									play(action)
 								}
 
 							}
@@ -124,10 +208,42 @@ func main() {
 					case 107: // k
 						i, j = moveSelector(N, i, j)
 					case 110: // n
-						gs = othello.New(othello.BLUE)
-						clock = newClock()
-						clock.TickFunc = refresh
+						session = othello.NewSession(othello.New(othello.BLUE))
+						resume()
+						clock.Stop()
+						clock = newClock(refresh)
+					case 111: // o
+						loaded, actions, err := loadGame(cfg.file)
+						if err != nil {
+							status = err.Error()
+							break
+						}
+						session = othello.NewSession(loaded.Start)
+						for k, action := range actions {
+							session.Play(action, loaded.Moves[k].Time)
+						}
+						resume()
+						clock.Stop()
+						clock = newClock(refresh)
+						status = "Loaded " + cfg.file
+					case 115: // s
+						if err := saveGame(cfg.file, gameRecord(cfg, session)); err != nil {
+							status = err.Error()
+						} else {
+							status = "Saved " + cfg.file
+						}
+					case 117: // u
+						// back to the human's turn, the AI would replay
+						// its own moves right away
+						if session.UndoTo(player) {
+							resume()
+						}
+					case 85: // U
+						if session.RedoTo(player) {
+							resume()
+						}
 					case 113: // q
+						mu.Unlock()
 						close(quit)
 						return
 					case 114: //r
@@ -137,6 +253,7 @@ func main() {
 			case *tcell.EventResize:
 				s.Sync()
 			}
+			mu.Unlock()
 
 			refresh()
 		}
@@ -149,24 +266,32 @@ func main() {
 
 type Clock struct {
 	ticker   *time.Ticker
-	Tick     bool
-	Duration time.Duration
-	TickFunc func()
+	done     chan struct{}
+	mu       sync.Mutex
+	tick     bool
+	duration time.Duration
 }
 
-func newClock() *Clock {
+// newClock - starts a clock calling tickFunc on every tick, until stopped
+func newClock(tickFunc func()) *Clock {
 	clock := &Clock{
 		ticker: time.NewTicker(time.Millisecond * 500),
-		Tick:   true,
+		done:   make(chan struct{}),
+		tick:   true,
 	}
 	t0 := time.Now()
 
 	go func() {
-		for t := range clock.ticker.C {
-			clock.Tick = !clock.Tick
-			clock.Duration = t.Sub(t0)
-			if clock.TickFunc != nil {
-				clock.TickFunc()
+		for {
+			select {
+			case t := <-clock.ticker.C:
+				clock.mu.Lock()
+				clock.tick = !clock.tick
+				clock.duration = t.Sub(t0)
+				clock.mu.Unlock()
+				tickFunc()
+			case <-clock.done:
+				return
 			}
 		}
 	}()
@@ -174,20 +299,97 @@ func newClock() *Clock {
 	return clock
 }
 
+// Stop - stops the clock and its goroutine
+func (c *Clock) Stop() {
+	c.ticker.Stop()
+	close(c.done)
+}
+
+// Elapsed - time since the clock started and whether the clock's separator
+// is shown, blinking every tick
+func (c *Clock) Elapsed() (time.Duration, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.duration, c.tick
+}
+
+// bounds of the AI's search, think time when one is given and a fixed number
+// of simulations otherwise
+func searchOptions(cfg config) gomcts.SearchOptions {
+	options := gomcts.SearchOptions{
+		Duration:       cfg.thinkTime,
+		MaxSimulations: depth,
+		Workers:        cfg.workers,
+		Parallelism:    gomcts.RootParallel,
+
+		TranspositionTableSize: transpositions,
+		Replacement:            gomcts.ReplaceLeastVisited,
+		Seed:                   cfg.seed,
+	}
+	if cfg.thinkTime > 0 {
+		options.MaxSimulations = 0
+	}
+	return options
+}
+
+// command line configuration
+type config struct {
+	eval       gomcts.RolloutPolicy
+	player     int
+	level      string
+	thinkTime  time.Duration
+	workers    int
+	seed       int64
+	file       string
+	endgame    int
+	algorithm  string
+	book       string
+	bookGames  int
+	randomBook bool
+	// evaluator - evaluation of the weights files given, nil for the
+	// heuristics with the weights of the level
+	evaluator othello.Evaluator
+}
+
 // parse and process arguments
-func parsArgs() (d gomcts.RolloutPolicy, p int, l string) {
+func parsArgs() config {
 	// Create new parser object
 	parser := argparse.NewParser("gothello", "")
 
 	// player ~ blue always starts
-	player := parser.String("p", "player", &argparse.Options{Required: false, Help: "Choose between : blue, red"})
+	player := parser.String("p", "player", &argparse.Options{Required: false, Help: "Choose between : blue, red", Default: "blue"})
 
 	// difficulty
-	difficulty := parser.String("d", "difficulty", &argparse.Options{Required: false, Help: "Choose between: easy, medium, hard"})
+	difficulty := parser.String("d", "difficulty", &argparse.Options{Required: false, Help: "Choose between: easy, medium, hard", Default: "easy"})
+
+	// think time
+	think := parser.String("t", "time", &argparse.Options{Required: false, Help: "Think time per move, e.g. 500ms or 2s. Defaults to a fixed number of simulations"})
+
+	// search workers
+	workers := parser.Int("w", "workers", &argparse.Options{Required: false, Help: "Number of goroutines searching in parallel", Default: 1})
+
+	// random seed
+	seed := parser.Int("S", "seed", &argparse.Options{Required: false, Help: "Seed of the AI's randomness, to replay a game. Random when unset"})
+
+	// search algorithm
+	algorithm := parser.String("a", "algorithm", &argparse.Options{Required: false, Help: "Choose between: mcts, alphabeta", Default: "mcts"})
+
+	// opening book
+	bookFile := parser.String("b", "book", &argparse.Options{Required: false, Help: "Opening book the AI plays from before searching, built with the book command"})
+	bookGames := parser.Int("B", "book-games", &argparse.Options{Required: false, Help: "Games below which a book move is left out", Default: book.MIN_GAMES})
+	randomBook := parser.Flag("R", "random-book", &argparse.Options{Required: false, Help: "Pick book moves at random, weighted by how often they were played, instead of the one winning most"})
+
+	// endgame solver
+	endgame := parser.Int("e", "endgame", &argparse.Options{Required: false, Help: "Empty squares from which the AI solves the game exactly instead of searching, 0 to always search", Default: othello.ENDGAME_EMPTIES})
 
-	eval := othello.OthelloRandomRolloutPolicy
-	level := "easy"
-	nextToMove := 1
+	// tuned evaluation
+	heuristics := parser.String("H", "heuristics", &argparse.Options{Required: false, Help: "Heuristic weights file, written by the tune command, the AI evaluates positions with instead of the built-in weights: in every alpha-beta search and in medium and hard MCTS rollouts"})
+	patterns := parser.String("P", "patterns", &argparse.Options{Required: false, Help: "Pattern weights file, written by the tune command, the AI evaluates positions with instead of the heuristics: in every alpha-beta search and in medium and hard MCTS rollouts"})
+
+	// game record
+	file := parser.String("f", "file", &argparse.Options{Required: false, Help: "GGF file games are saved to and loaded from", Default: "gothello.ggf"})
+
+	cfg := config{eval: othello.OthelloRandomRolloutPolicy, player: 1, level: "easy"}
 
 	// Parse input
 	err := parser.Parse(os.Args)
@@ -197,30 +399,515 @@ func parsArgs() (d gomcts.RolloutPolicy, p int, l string) {
 	} else {
 		switch *difficulty {
 		case "medium":
-			eval = othello.OthelloMediumRolloutPolicy
-			level = "medium"
+			cfg.eval = othello.OthelloMediumRolloutPolicy
+			cfg.level = "medium"
 		case "hard":
-			eval = othello.OthelloHardRolloutPolicy
-			level = "hard"
+			cfg.eval = othello.OthelloHardRolloutPolicy
+			cfg.level = "hard"
 		case "easy":
-			eval = othello.OthelloRandomRolloutPolicy
-			level = "easy"
+			cfg.eval = othello.OthelloRandomRolloutPolicy
+			cfg.level = "easy"
 		default:
 			panic("Invalid argument for -d flag. See help")
 		}
 
 		switch *player {
 		case "red":
-			nextToMove = 2
+			cfg.player = 2
 		case "blue":
-			nextToMove = 1
+			cfg.player = 1
 		default:
 			panic("Invalid argument for -p flag. See help")
 		}
+
+		if *think != "" {
+			cfg.thinkTime, err = time.ParseDuration(*think)
+			if err != nil || cfg.thinkTime <= 0 {
+				panic("Invalid argument for -t flag. See help")
+			}
+		}
+
+		if *workers < 1 {
+			panic("Invalid argument for -w flag. See help")
+		}
+		cfg.workers = *workers
+
+		switch *algorithm {
+		case "mcts", "alphabeta":
+			cfg.algorithm = *algorithm
+		default:
+			panic("Invalid argument for -a flag. See help")
+		}
+
+		if *endgame < 0 {
+			panic("Invalid argument for -e flag. See help")
+		}
+		cfg.endgame = *endgame
+
+		cfg.seed = int64(*seed)
+		if cfg.seed == 0 {
+			cfg.seed = time.Now().UnixNano()
+		}
+
+		cfg.file = *file
+		cfg.book = *bookFile
+		cfg.randomBook = *randomBook
+		if *bookGames < 0 {
+			panic("Invalid argument for -B flag. See help")
+		}
+		cfg.bookGames = *bookGames
+
+		if *heuristics != "" && *patterns != "" {
+			panic("Invalid arguments, -H and -P can't be combined. See help")
+		}
+		if (*heuristics != "" || *patterns != "") && cfg.level == "easy" && cfg.algorithm == "mcts" {
+			panic("Invalid arguments, the easy MCTS AI plays random rollouts and ignores -H and -P. See help")
+		}
+
+		if *heuristics != "" {
+			weights, err := loadWeights(*heuristics)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				os.Exit(1)
+			}
+			cfg.evaluator = othello.HeuristicEvaluator(weights)
+			if cfg.level != "easy" {
+				cfg.eval = othello.EvaluatorRolloutPolicy(cfg.evaluator)
+			}
+		}
+
+		if *patterns != "" {
+			weights, err := loadPatterns(*patterns)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				os.Exit(1)
+			}
+			cfg.evaluator = othello.PatternEvaluator(weights)
+			if cfg.level != "easy" {
+				cfg.eval = othello.EvaluatorRolloutPolicy(cfg.evaluator)
+			}
+		}
+	}
+
+	return cfg
+}
+
+// AI's move in state solved exactly and its report
+func solveEndgame(state othello.OthelloGameState) (gomcts.Action, []string) {
+	start := time.Now()
+	endgame := othello.SolveEndgame(state)
+
+	outcome := "draws"
+	if endgame.Score > 0 {
+		outcome = fmt.Sprintf("wins by %d", endgame.Score)
+	} else if endgame.Score < 0 {
+		outcome = fmt.Sprintf("loses by %d", -endgame.Score)
+	}
+
+	return endgame.Action, []string{
+		fmt.Sprintf("%v - %s", endgame.Action, outcome),
+		fmt.Sprintf("solved exactly in %.2fs", time.Since(start).Seconds()),
+	}
+}
+
+// AI's move in state searched with alpha-beta and its report
+func alphaBeta(state othello.OthelloGameState, cfg config) (gomcts.Action, []string) {
+	options := othello.AlphaBetaOptions{
+		Depth:                  alphaBetaDepths[cfg.level],
+		Duration:               cfg.thinkTime,
+		TranspositionTableSize: transpositions,
+		Evaluator:              othello.HeuristicEvaluator(othello.MEDIUM_WEIGHTS),
+	}
+	if cfg.thinkTime > 0 {
+		options.Depth = 0
+	}
+	if cfg.level == "hard" {
+		options.Evaluator = othello.HeuristicEvaluator(othello.HARD_WEIGHTS)
+	}
+	if cfg.evaluator != nil {
+		options.Evaluator = cfg.evaluator
+	}
+
+	result := othello.AlphaBeta(context.Background(), state, options)
+
+	line := fmt.Sprintf("%v - score %.1f", result.Action, result.Score)
+	if result.Exact {
+		line = fmt.Sprintf("%v - solved", result.Action)
+	}
+	return result.Action, []string{
+		line,
+		fmt.Sprintf("depth %d, %d nodes in %.2fs", result.Depth, result.Nodes, result.Elapsed.Seconds()),
+	}
+}
+
+// report of the AI's move found by analysis
+func describeSearch(analysis gomcts.SearchResult) []string {
+	report := make([]string, 0, 2)
+	for _, stats := range analysis.Actions {
+		if stats.Action == analysis.Action {
+			action := stats.Action.(othello.OthelloBoardGameAction)
+			line := fmt.Sprintf("%v - win rate %.1f%%", action, 100*stats.WinRate)
+			if stats.Proven {
+				line = fmt.Sprintf("%v - %s", action, provenOutcome(stats.Result, action.GetValue()))
+			}
+			report = append(report, line)
+		}
+	}
+	return append(report, fmt.Sprintf("%d simulations in %.2fs", analysis.Simulations, analysis.Elapsed.Seconds()))
+}
+
+// record of the game of session between the human and the AI
+func gameRecord(cfg config, session *othello.Session) othello.GameRecord {
+	record := session.Record()
+	record.Date = time.Now().Format("2006.01.02_15:04:05.MST")
+	record.Black, record.White = "human", "gothello-"+cfg.level
+	if cfg.player == othello.RED {
+		record.Black, record.White = record.White, record.Black
+	}
+	return record
+}
+
+// writes record to the GGF file path
+func saveGame(path string, record othello.GameRecord) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err = othello.WriteGGF(f, record); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// reads the first game of the GGF file path and the actions to replay it
+func loadGame(path string) (othello.GameRecord, []othello.OthelloBoardGameAction, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return othello.GameRecord{}, nil, err
+	}
+	defer f.Close()
+
+	records, err := othello.ReadGGF(f)
+	if err != nil {
+		return othello.GameRecord{}, nil, err
+	}
+	if len(records) == 0 {
+		return othello.GameRecord{}, nil, fmt.Errorf("no game in %v", path)
+	}
+
+	actions, err := records[0].Actions()
+	return records[0], actions, err
+}
+
+// counts the positions reached from the initial position at each depth up to
+// the one requested, validating the move generator
+func perft(args []string) {
+	parser := argparse.NewParser("gothello perft", "Count positions reached from the initial position, passes count as a ply")
+	depth := parser.Int("n", "depth", &argparse.Options{Required: false, Help: "Deepest ply to count", Default: 9})
+	mailbox := parser.Flag("m", "mailbox", &argparse.Options{Required: false, Help: "Count on the mailbox representation instead of bitboards"})
+
+	err := parser.Parse(args)
+	if err != nil {
+		fmt.Print(parser.Usage(err))
+		os.Exit(1)
+	}
+
+	var gs gomcts.GameState = othello.NewBitboard(othello.BLUE)
+	if *mailbox {
+		gs = othello.New(othello.BLUE)
+	}
+
+	for d := 1; d <= *depth; d++ {
+		start := time.Now()
+		nodes := othello.Perft(gs, d)
+		fmt.Printf("perft(%2d) = %12d  %v\n", d, nodes, time.Since(start).Round(time.Millisecond))
+	}
+}
+
+// reads the opening book of cfg, nil when there is none
+func loadBook(cfg config) (*book.Book, error) {
+	if cfg.book == "" {
+		return nil, nil
+	}
+
+	f, err := os.Open(cfg.book)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	openings, err := book.Load(f)
+	if err != nil {
+		return nil, err
+	}
+	openings.Randomize = cfg.randomBook
+	openings.MinGames = cfg.bookGames
+	return openings, nil
+}
+
+// reads the pattern weights in file
+func loadPatterns(file string) (*othello.PatternWeights, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return othello.LoadPatternWeights(f)
+}
+
+// reads the heuristic weights in file
+func loadWeights(file string) (othello.Weights, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return othello.Weights{}, err
+	}
+	defer f.Close()
+
+	return othello.LoadWeights(f)
+}
+
+// reads the games of the WTHOR .wtb file, naming their players and
+// tournaments from the .JOU and .TRN files when given
+func loadWthor(file, playersFile, tournamentsFile string) ([]othello.GameRecord, error) {
+	players, err := loadWthorNames(playersFile, othello.ReadWthorPlayers)
+	if err != nil {
+		return nil, err
+	}
+	tournaments, err := loadWthorNames(tournamentsFile, othello.ReadWthorTournaments)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	reader, err := othello.NewWthorReader(f)
+	if err != nil {
+		return nil, err
+	}
+	records := make([]othello.GameRecord, 0)
+	for {
+		game, err := reader.Next()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, game.Record(players, tournaments))
+	}
+}
+
+// reads the names of the WTHOR .JOU or .TRN file with read, none without a
+// file
+func loadWthorNames(file string, read func(io.Reader) ([]string, error)) ([]string, error) {
+	if file == "" {
+		return nil, nil
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return read(f)
+}
+
+// book move of state, false without a book or when state is out of it
+func chooseBookMove(openings *book.Book, state othello.OthelloGameState, rng *rand.Rand) (othello.OthelloBoardGameAction, bool) {
+	if openings == nil {
+		return othello.OthelloBoardGameAction{}, false
+	}
+	return openings.Choose(state, rng)
+}
+
+// report of the AI's book move in state
+func describeBookMove(openings *book.Book, state othello.OthelloGameState, move othello.OthelloBoardGameAction) []string {
+	for _, stats := range openings.Moves(state) {
+		if stats.Move == move.GetMove() {
+			return []string{
+				fmt.Sprintf("%v - book, win rate %.1f%%", move, 100*stats.WinRate()),
+				fmt.Sprintf("played in %d games", stats.Games),
+			}
+		}
+	}
+	return []string{fmt.Sprintf("%v - book", move)}
+}
+
+// builds an opening book from game records and self-play, adding to the book
+// already in the output file
+func buildBook(args []string) {
+	parser := argparse.NewParser("gothello book", "Build an opening book from game records and self-play")
+	out := parser.String("o", "out", &argparse.Options{Required: false, Help: "Book file to add to", Default: "gothello.book"})
+	ggf := parser.String("g", "ggf", &argparse.Options{Required: false, Help: "GGF file of games to add"})
+	wthor := parser.String("W", "wthor", &argparse.Options{Required: false, Help: "WTHOR .wtb file of games to add"})
+	players := parser.String("J", "players", &argparse.Options{Required: false, Help: "WTHOR .JOU file naming the players of the -W games"})
+	tournaments := parser.String("T", "tournaments", &argparse.Options{Required: false, Help: "WTHOR .TRN file naming the tournaments of the -W games"})
+	games := parser.Int("s", "self-play", &argparse.Options{Required: false, Help: "Number of self-play games to add"})
+	simulations := parser.Int("m", "simulations", &argparse.Options{Required: false, Help: "Simulations per move of self-play games", Default: depth})
+	plies := parser.Int("n", "depth", &argparse.Options{Required: false, Help: "Plies of each game to add", Default: book.DEPTH})
+	seed := parser.Int("S", "seed", &argparse.Options{Required: false, Help: "Seed of the self-play games. Random when unset"})
+
+	err := parser.Parse(args)
+	if err != nil {
+		fmt.Print(parser.Usage(err))
+		os.Exit(1)
+	}
+	if *wthor == "" && (*players != "" || *tournaments != "") {
+		panic("Invalid arguments, -J and -T name the games of -W. See help")
+	}
+
+	openings, err := loadBook(config{book: *out})
+	if os.IsNotExist(err) {
+		openings, err = book.New(), nil
+	}
+	check := func(err error) {
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
 	}
+	check(err)
 
-	return eval, nextToMove, level
+	if *ggf != "" {
+		f, err := os.Open(*ggf)
+		check(err)
+		records, err := othello.ReadGGF(f)
+		f.Close()
+		check(err)
+		for _, record := range records {
+			check(openings.AddRecord(record, *plies))
+		}
+		fmt.Printf("added %d games of %v\n", len(records), *ggf)
+	}
 
+	if *wthor != "" {
+		records, err := loadWthor(*wthor, *players, *tournaments)
+		check(err)
+		for _, record := range records {
+			check(openings.AddRecord(record, *plies))
+		}
+		fmt.Printf("added %d games of %v\n", len(records), *wthor)
+	}
+
+	if *games > 0 {
+		if *seed == 0 {
+			*seed = int(time.Now().UnixNano())
+		}
+		rng := rand.New(rand.NewSource(int64(*seed)))
+		options := gomcts.SearchOptions{MaxSimulations: *simulations}
+		openings.SelfPlay(*games, *plies, func(state gomcts.GameState) gomcts.Action {
+			options.Seed = rng.Int63()
+			return gomcts.MonteCarloTreeSearchWithOptions(context.Background(), state, othello.OthelloRandomRolloutPolicy, options)
+		})
+		fmt.Printf("added %d self-play games\n", *games)
+	}
+
+	f, err := os.Create(*out)
+	check(err)
+	check(openings.Save(f))
+	check(f.Close())
+	fmt.Printf("%v holds %d positions\n", *out, openings.Len())
+}
+
+// fits evaluation weights to positions of game records and self-play games
+// labeled with how the games ended
+func tune(args []string) {
+	parser := argparse.NewParser("gothello tune", "Fit evaluation weights to the results of game records and self-play games")
+	kind := parser.String("k", "kind", &argparse.Options{Required: false, Help: "Weights to fit, choose between: heuristics, patterns", Default: "heuristics"})
+	out := parser.String("o", "out", &argparse.Options{Required: false, Help: "Weights file to write", Default: "gothello.weights"})
+	ggf := parser.String("g", "ggf", &argparse.Options{Required: false, Help: "GGF file of games to fit to"})
+	wthor := parser.String("W", "wthor", &argparse.Options{Required: false, Help: "WTHOR .wtb file of games to fit to"})
+	players := parser.String("J", "players", &argparse.Options{Required: false, Help: "WTHOR .JOU file naming the players of the -W games"})
+	tournaments := parser.String("T", "tournaments", &argparse.Options{Required: false, Help: "WTHOR .TRN file naming the tournaments of the -W games"})
+	games := parser.Int("s", "self-play", &argparse.Options{Required: false, Help: "Number of self-play games to fit to"})
+	simulations := parser.Int("m", "simulations", &argparse.Options{Required: false, Help: "Simulations per move of self-play games", Default: depth})
+	randomPlies := parser.Int("r", "random", &argparse.Options{Required: false, Help: "Random moves opening each self-play game", Default: 8})
+	epochs := parser.Int("n", "epochs", &argparse.Options{Required: false, Help: "Passes over the positions", Default: tuning.EPOCHS})
+	seed := parser.Int("S", "seed", &argparse.Options{Required: false, Help: "Seed of the self-play games and the fit. Random when unset"})
+
+	err := parser.Parse(args)
+	if err != nil {
+		fmt.Print(parser.Usage(err))
+		os.Exit(1)
+	}
+	if *kind != "heuristics" && *kind != "patterns" {
+		panic("Invalid argument for -k flag. See help")
+	}
+	if *wthor == "" && (*players != "" || *tournaments != "") {
+		panic("Invalid arguments, -J and -T name the games of -W. See help")
+	}
+
+	check := func(err error) {
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+	}
+	samples := make([]tuning.Sample, 0)
+
+	if *ggf != "" {
+		f, err := os.Open(*ggf)
+		check(err)
+		records, err := othello.ReadGGF(f)
+		f.Close()
+		check(err)
+		for _, record := range records {
+			recordSamples, err := tuning.RecordSamples(record)
+			check(err)
+			samples = append(samples, recordSamples...)
+		}
+		fmt.Printf("added %d games of %v\n", len(records), *ggf)
+	}
+
+	if *wthor != "" {
+		records, err := loadWthor(*wthor, *players, *tournaments)
+		check(err)
+		for _, record := range records {
+			recordSamples, err := tuning.RecordSamples(record)
+			check(err)
+			samples = append(samples, recordSamples...)
+		}
+		fmt.Printf("added %d games of %v\n", len(records), *wthor)
+	}
+
+	if *seed == 0 {
+		*seed = int(time.Now().UnixNano())
+	}
+	rng := rand.New(rand.NewSource(int64(*seed)))
+
+	if *games > 0 {
+		options := gomcts.SearchOptions{MaxSimulations: *simulations}
+		samples = append(samples, tuning.SelfPlay(*games, *randomPlies, func(state gomcts.GameState) gomcts.Action {
+			options.Seed = rng.Int63()
+			return gomcts.MonteCarloTreeSearchWithOptions(context.Background(), state, othello.OthelloRandomRolloutPolicy, options)
+		}, rng)...)
+		fmt.Printf("added %d self-play games\n", *games)
+	}
+
+	if len(samples) == 0 {
+		check(errors.New("no games to fit to, see -g, -W and -s"))
+	}
+
+	options := tuning.Options{Epochs: *epochs, Seed: rng.Int63()}
+	var weights interface{ Save(io.Writer) error }
+	var loss float64
+	if *kind == "patterns" {
+		weights, loss = tuning.FitPatterns(samples, options)
+	} else {
+		weights, loss = tuning.FitHeuristics(samples, options)
+	}
+
+	f, err := os.Create(*out)
+	check(err)
+	check(weights.Save(f))
+	check(f.Close())
+	fmt.Printf("fit %v to %d positions, loss %.4f, written to %v\n", *kind, len(samples), loss, *out)
 }
 
 // check if new bounded position
@@ -313,8 +1000,19 @@ func puts(s tcell.Screen, fg tcell.Color, x, y int, str string) {
 	}
 }
 
+// describes a proven game result from the point of view of player
+func provenOutcome(result gomcts.GameResult, player int) string {
+	switch result {
+	case gomcts.GameResult(player):
+		return "proven win"
+	case gomcts.GameResult(othello.EMPTY):
+		return "proven draw"
+	}
+	return "proven loss"
+}
+
 // prints current game state
-func printGame(s tcell.Screen, gs othello.OthelloGameState, level string, showLegalMoves bool, ci, cj int) {
+func printGame(s tcell.Screen, gs othello.OthelloGameState, moves []othello.OthelloBoardGameAction, level string, showLegalMoves bool, ci, cj int, report []string, status string) {
 	const header = 3
 	const w = tcell.ColorWhite
 	const b = tcell.ColorBlue
@@ -329,20 +1027,21 @@ func printGame(s tcell.Screen, gs othello.OthelloGameState, level string, showLe
 	SYMBOLS := []string{" ", "•", "•", "x"}
 	COLORS := []tcell.Color{w, b, r, w}
 
-	// board numbers
+	// board coordinates, columns a-h and rows 1-8
 	for i := 0; i < BOARD_SIZE; i++ {
-		n := fmt.Sprintf("%d", i)
-		puts(s, w, XOFF+i*4+2, YOFF+header-1, n)
-		puts(s, w, XOFF+i*4+2, YOFF+2*BOARD_SIZE+header+1, n)
-		puts(s, w, XOFF-header, YOFF+i*2+header+1, n)
-		puts(s, w, XOFF+BOARD_SIZE*4+2, YOFF+i*2+header+1, n)
+		col := fmt.Sprintf("%c", 'a'+i)
+		row := fmt.Sprintf("%d", i+1)
+		puts(s, w, XOFF+i*4+2, YOFF+header-1, col)
+		puts(s, w, XOFF+i*4+2, YOFF+2*BOARD_SIZE+header+1, col)
+		puts(s, w, XOFF-header, YOFF+i*2+header+1, row)
+		puts(s, w, XOFF+BOARD_SIZE*4+2, YOFF+i*2+header+1, row)
 	}
 
 	// game state
 	for i := 0; i < BOARD_SIZE; i++ {
 		puts(s, w, XOFF, YOFF+header+2*i, board_row_top)
 		for j := 0; j < BOARD_SIZE+1; j++ {
-			piece := board[i+1+10*(j+1)]
+			piece := board[othello.Square(i, j)]
 			if piece == othello.BLUE || piece == othello.RED {
 				puts(s, COLORS[piece], XOFF+2*i*2+2, YOFF+2*j+header+1, SYMBOLS[piece])
 			}
@@ -357,12 +1056,15 @@ func printGame(s tcell.Screen, gs othello.OthelloGameState, level string, showLe
 		// Legal actions for current player
 		for i := 0; i < len(actions); i++ {
 			action := actions[i].(othello.OthelloBoardGameAction)
-			move := action.GetMove()
 			value := action.GetValue()
 
-			y := move/10 - 1
-			x := move%10 - 1
+			// no square to mark, the player has to pass
+			if action.IsPass() {
+				puts(s, COLORS[value], XOFF, YOFF+header+2*BOARD_SIZE+5, "No moves, Enter/Space - Pass")
+				continue
+			}
 
+			x, y := othello.Coordinates(action.GetMove())
 			puts(s, COLORS[value], XOFF+2*x*2+2, YOFF+2*y+header+1, SYMBOLS[3])
 
 		}
@@ -390,6 +1092,36 @@ func printGame(s tcell.Screen, gs othello.OthelloGameState, level string, showLe
 	puts(s, w, XOFF+BOARD_SIZE*4+16+3, YOFF+header+9, "q - Quit")
 	puts(s, w, XOFF+BOARD_SIZE*4+16+3, YOFF+header+10, "n - New game")
 	puts(s, w, XOFF+BOARD_SIZE*4+16+3, YOFF+header+11, "r - Reload")
+	puts(s, w, XOFF+BOARD_SIZE*4+16+3, YOFF+header+12, "s - Save game")
+	puts(s, w, XOFF+BOARD_SIZE*4+16+3, YOFF+header+13, "o - Open saved game")
+	puts(s, w, XOFF+BOARD_SIZE*4+16+3, YOFF+header+14, "u - Undo")
+	puts(s, w, XOFF+BOARD_SIZE*4+16+3, YOFF+header+15, "U - Redo")
+
+	// report of the AI's last move
+	if len(report) > 0 {
+		puts(s, w, XOFF+BOARD_SIZE*4+16+2, YOFF+header+17, "Last AI move")
+		for k, line := range report {
+			puts(s, w, XOFF+BOARD_SIZE*4+16+3, YOFF+header+18+k, line)
+		}
+	}
+
+	// outcome of the last save or load
+	if status != "" {
+		puts(s, w, XOFF+BOARD_SIZE*4+16+2, YOFF+header+21, status)
+	}
+
+	// moves played, the last few lines of them
+	const movesPerLine = 10
+	const moveLines = 4
+	first := 0
+	if lines := (len(moves) + movesPerLine - 1) / movesPerLine; lines > moveLines {
+		first = (lines - moveLines) * movesPerLine
+	}
+	puts(s, w, XOFF, YOFF+header+2*BOARD_SIZE+7, "Moves")
+	for k := first; k < len(moves); k++ {
+		line := (k - first) / movesPerLine
+		puts(s, COLORS[moves[k].GetValue()], XOFF+5*(k%movesPerLine), YOFF+header+2*BOARD_SIZE+8+line, moves[k].String())
+	}
 
 	// score
 	p1, p2 := gs.GetScore()
@@ -398,10 +1130,11 @@ func printGame(s tcell.Screen, gs othello.OthelloGameState, level string, showLe
 	puts(s, COLORS[othello.RED], XOFF+BOARD_SIZE*2+5, YOFF, SYMBOLS[othello.RED])
 
 	// time
-	mins := int(clock.Duration.Minutes())
-	secs := int(clock.Duration.Seconds()) % 60
+	elapsed, tick := clock.Elapsed()
+	mins := int(elapsed.Minutes())
+	secs := int(elapsed.Seconds()) % 60
 	deli := ":"
-	if !clock.Tick {
+	if !tick {
 		deli = " "
 	}
 	time := fmt.Sprintf("%02d%s%02d", mins, deli, secs)
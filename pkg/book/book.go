@@ -0,0 +1,262 @@
+package book
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/unathi-skosana/gothello/pkg/gomcts"
+	"github.com/unathi-skosana/gothello/pkg/othello"
+)
+
+// Books are stored as text, a line per position and move:
+//
+//	---------------------------OX------XO--------------------------- X d3 120 64.5
+//
+// the position in the format of othello.ParsePosition, the move, the games
+// it was played in and the games it won, draws counting half. Positions are
+// stored in the canonical form of their symmetries, so games reaching the
+// same position in another orientation share their statistics. Lines
+// starting with # are comments.
+
+// MIN_GAMES - games below which a move is left out by Choose by default
+const MIN_GAMES = 3
+
+// confidence of the lower bound of the win rate, z for 95%
+const confidence = 1.96
+
+// Book - statistics of the moves played from opening positions
+type Book struct {
+	// MinGames - games below which a move is left out by Choose
+	MinGames int
+	// Randomize - whether Choose picks moves at random, weighted by the games
+	// they were played in, rather than the one with the highest LowerBound
+	Randomize bool
+
+	positions map[othello.OthelloBitboardGameState]map[int]*MoveStats
+}
+
+// MoveStats - games a move was played in and the games it won
+type MoveStats struct {
+	Move  int
+	Games int
+	// Wins - games won by the player making the move, draws counting half
+	Wins float64
+}
+
+// New - initializes a new empty Book
+func New() *Book {
+	return &Book{MinGames: MIN_GAMES, positions: make(map[othello.OthelloBitboardGameState]map[int]*MoveStats)}
+}
+
+// WinRate - Get the fraction of the games the move won
+func (s MoveStats) WinRate() float64 {
+	if s.Games == 0 {
+		return 0
+	}
+	return s.Wins / float64(s.Games)
+}
+
+// LowerBound - Get the lower bound of the 95% Wilson score interval of the
+// win rate, so moves won in a few games don't outrank well-tested ones
+func (s MoveStats) LowerBound() float64 {
+	if s.Games == 0 {
+		return 0
+	}
+
+	n := float64(s.Games)
+	p := s.WinRate()
+	z2 := confidence * confidence
+	centre := p + z2/(2*n)
+	spread := confidence * math.Sqrt(p*(1-p)/n+z2/(4*n*n))
+	return (centre - spread) / (1 + z2/n)
+}
+
+// Len - Get the number of positions in the book
+func (b *Book) Len() int {
+	return len(b.positions)
+}
+
+// Add - count a game in which action was played in state and which ended
+// with result
+func (b *Book) Add(state gomcts.GameState, action othello.OthelloBoardGameAction, result gomcts.GameResult) {
+	if action.IsPass() {
+		return
+	}
+
+	key, symmetries := canonical(state)
+	move := canonicalMove(symmetries, action.GetMove())
+
+	moves, ok := b.positions[key]
+	if !ok {
+		moves = make(map[int]*MoveStats)
+		b.positions[key] = moves
+	}
+	stats, ok := moves[move]
+	if !ok {
+		stats = &MoveStats{Move: move}
+		moves[move] = stats
+	}
+
+	stats.Games++
+	if result == gomcts.GameResult(action.GetValue()) {
+		stats.Wins++
+	} else if result == gomcts.GameResult(othello.EMPTY) {
+		stats.Wins += 0.5
+	}
+}
+
+// Moves - Get the statistics of the moves of state in the book, the most
+// played first
+func (b *Book) Moves(state gomcts.GameState) []MoveStats {
+	key, symmetries := canonical(state)
+
+	moves := make([]MoveStats, 0, len(b.positions[key]))
+	for _, stats := range b.positions[key] {
+		move := *stats
+		move.Move = othello.UntransformMove(symmetries[0], stats.Move)
+		moves = append(moves, move)
+	}
+
+	sort.Slice(moves, func(i, j int) bool {
+		if moves[i].Games != moves[j].Games {
+			return moves[i].Games > moves[j].Games
+		}
+		return moves[i].Move < moves[j].Move
+	})
+	return moves
+}
+
+// Choose - pick a book move of state, false when the book has none played in
+// at least MinGames games. rng is only used when Randomize is set.
+func (b *Book) Choose(state gomcts.GameState, rng *rand.Rand) (othello.OthelloBoardGameAction, bool) {
+	candidates := make([]MoveStats, 0)
+	total := 0
+	for _, stats := range b.Moves(state) {
+		action := othello.NewAction(stats.Move, state.NextToMove())
+		if stats.Games >= b.MinGames && stats.Games > 0 && othello.IsLegalAction(state, action) {
+			candidates = append(candidates, stats)
+			total += stats.Games
+		}
+	}
+
+	if len(candidates) == 0 {
+		return othello.OthelloBoardGameAction{}, false
+	}
+
+	chosen := candidates[0]
+	if b.Randomize {
+		pick := rng.Intn(total)
+		for _, stats := range candidates {
+			if pick -= stats.Games; pick < 0 {
+				chosen = stats
+				break
+			}
+		}
+	} else {
+		for _, stats := range candidates[1:] {
+			if stats.LowerBound() > chosen.LowerBound() {
+				chosen = stats
+			}
+		}
+	}
+
+	return othello.NewAction(chosen.Move, state.NextToMove()), true
+}
+
+// Save - write the book in its text format
+func (b *Book) Save(w io.Writer) error {
+	lines := make([]string, 0, len(b.positions))
+	for key, moves := range b.positions {
+		for _, stats := range moves {
+			lines = append(lines, fmt.Sprintf("%v %v %d %s", key, othello.MoveString(stats.Move), stats.Games, strconv.FormatFloat(stats.Wins, 'f', -1, 64)))
+		}
+	}
+	sort.Strings(lines)
+
+	buf := bufio.NewWriter(w)
+	for _, line := range lines {
+		fmt.Fprintln(buf, line)
+	}
+	return buf.Flush()
+}
+
+// Load - read a book written by Save
+func Load(r io.Reader) (*Book, error) {
+	b := New()
+	scanner := bufio.NewScanner(r)
+
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 5 {
+			return nil, fmt.Errorf("book: line %v: expected position, side to move, move, games and wins", n)
+		}
+
+		state, err := othello.ParsePosition(fields[0] + " " + fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("book: line %v: %v", n, err)
+		}
+		move, err := othello.ParseMove(fields[2])
+		if err != nil || move == othello.PASS {
+			return nil, fmt.Errorf("book: line %v: invalid move %q", n, fields[2])
+		}
+		games, err := strconv.Atoi(fields[3])
+		if err != nil || games < 0 {
+			return nil, fmt.Errorf("book: line %v: invalid games %q", n, fields[3])
+		}
+		wins, err := strconv.ParseFloat(fields[4], 64)
+		if err != nil || wins < 0 || wins > float64(games) {
+			return nil, fmt.Errorf("book: line %v: invalid wins %q", n, fields[4])
+		}
+
+		key, symmetries := canonical(state)
+		moves, ok := b.positions[key]
+		if !ok {
+			moves = make(map[int]*MoveStats)
+			b.positions[key] = moves
+		}
+		move = canonicalMove(symmetries, move)
+		stats, ok := moves[move]
+		if !ok {
+			stats = &MoveStats{Move: move}
+			moves[move] = stats
+		}
+		stats.Games += games
+		stats.Wins += wins
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// canonical - canonical form of state and the symmetries taking state to it
+func canonical(state gomcts.GameState) (othello.OthelloBitboardGameState, []int) {
+	if m, ok := state.(othello.OthelloGameState); ok {
+		return m.Bitboard().Canonical()
+	}
+	return state.(othello.OthelloBitboardGameState).Canonical()
+}
+
+// canonicalMove - smallest square move is taken to by symmetries, so that
+// moves a symmetric position can't tell apart share their statistics
+func canonicalMove(symmetries []int, move int) int {
+	best := othello.TransformMove(symmetries[0], move)
+	for _, t := range symmetries[1:] {
+		if m := othello.TransformMove(t, move); m < best {
+			best = m
+		}
+	}
+	return best
+}
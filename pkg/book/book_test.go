@@ -0,0 +1,183 @@
+package book
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/unathi-skosana/gothello/pkg/gomcts"
+	"github.com/unathi-skosana/gothello/pkg/othello"
+)
+
+// plays the moves in notation from the initial position
+func play(t *testing.T, moves ...string) gomcts.GameState {
+	var state gomcts.GameState = othello.New(othello.BLUE)
+	for _, notation := range moves {
+		move, err := othello.ParseMove(notation)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state = othello.NewAction(move, state.NextToMove()).ApplyTo(state)
+	}
+	return state
+}
+
+func TestSymmetricPositionsShareMoves(t *testing.T) {
+	b := New()
+	b.MinGames = 1
+	b.Add(play(t, "d3"), othello.NewAction(53, othello.RED), gomcts.GameResult(othello.RED))
+
+	// c4 is d3 reflected in the a1-h8 diagonal, which takes c5 to e3
+	action, ok := b.Choose(play(t, "c4"), nil)
+	if !ok || action.String() != "e3" {
+		t.Errorf("book should answer c4 with e3 but answers %v, %v", action, ok)
+	}
+}
+
+func TestChooseBestAndRandom(t *testing.T) {
+	b := New()
+	state := play(t)
+	for i := 0; i < 4; i++ {
+		b.Add(state, othello.NewAction(34, othello.BLUE), gomcts.GameResult(othello.RED))
+	}
+	b.Add(state, othello.NewAction(43, othello.BLUE), gomcts.GameResult(othello.BLUE))
+
+	// the initial position is symmetric, c4 is stored as d3
+	if moves := b.Moves(state); len(moves) != 1 || moves[0].Games != 5 || moves[0].Wins != 1 {
+		t.Errorf("d3 and c4 should share their statistics but are %v", moves)
+	}
+
+	after, _ := play(t, "d3").(othello.OthelloGameState)
+	b = New()
+	add(b, after, "c5", 20, 12)
+	add(b, after, "c3", 1, 1)
+
+	if action, _ := b.Choose(after, nil); action.String() != "c5" {
+		t.Errorf("book should leave out c3 played once but chooses %v", action)
+	}
+
+	// a single won game doesn't outrank a well-tested move
+	b.MinGames = 0
+	if action, _ := b.Choose(after, nil); action.String() != "c5" {
+		t.Errorf("book should choose the well-tested c5 but chooses %v", action)
+	}
+
+	add(b, after, "c3", 19, 15)
+	if action, _ := b.Choose(after, nil); action.String() != "c3" {
+		t.Errorf("book should choose c3 winning more often but chooses %v", action)
+	}
+
+	b = New()
+	b.MinGames = 0
+	b.Randomize = true
+	add(b, after, "c5", 4, 0)
+	add(b, after, "c3", 1, 1)
+	rng := rand.New(rand.NewSource(1))
+	counts := make(map[string]int)
+	for i := 0; i < 1000; i++ {
+		action, _ := b.Choose(after, rng)
+		counts[action.String()]++
+	}
+	if counts["c5"] < 700 || counts["c3"] < 100 {
+		t.Errorf("book should choose c5 four times as often as c3 but chooses %v", counts)
+	}
+}
+
+// adds games of RED's move in state, wins of them won by RED
+func add(b *Book, state gomcts.GameState, move string, games, wins int) {
+	square, _ := othello.ParseMove(move)
+	for i := 0; i < games; i++ {
+		result := gomcts.GameResult(othello.BLUE)
+		if i < wins {
+			result = gomcts.GameResult(othello.RED)
+		}
+		b.Add(state, othello.NewAction(square, othello.RED), result)
+	}
+}
+
+func TestLowerBound(t *testing.T) {
+	lucky := MoveStats{Games: 1, Wins: 1}
+	tested := MoveStats{Games: 100, Wins: 60}
+	if lucky.LowerBound() >= tested.LowerBound() {
+		t.Errorf("one won game should rank below 60 of 100 but bounds are %v and %v", lucky.LowerBound(), tested.LowerBound())
+	}
+	if bound := tested.LowerBound(); bound <= 0.5 || bound >= 0.6 {
+		t.Errorf("bound of 60 wins of 100 should be between 0.5 and 0.6 but is %v", bound)
+	}
+}
+
+func TestChooseOutOfBook(t *testing.T) {
+	if _, ok := New().Choose(play(t, "d3"), nil); ok {
+		t.Errorf("empty book should have no move")
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	b := New()
+	b.SelfPlay(20, 6, func(state gomcts.GameState) gomcts.Action {
+		return othello.OthelloRandomRolloutPolicy(state, rand.New(rand.NewSource(int64(len(state.GetLegalActions())))))
+	})
+
+	var buf bytes.Buffer
+	if err := b.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load(&buf)
+	if err != nil {
+		t.Fatalf("book should load but %v", err)
+	}
+
+	if !reflect.DeepEqual(loaded.positions, b.positions) {
+		t.Errorf("loaded book should equal the saved one")
+	}
+}
+
+func TestSelfPlayCountsGames(t *testing.T) {
+	b := New()
+	rng := rand.New(rand.NewSource(1))
+	b.SelfPlay(30, 4, func(state gomcts.GameState) gomcts.Action {
+		return othello.OthelloRandomRolloutPolicy(state, rng)
+	})
+
+	games := 0
+	for _, stats := range b.Moves(othello.New(othello.BLUE)) {
+		games += stats.Games
+	}
+	if games != 30 {
+		t.Errorf("first moves should count 30 games but count %v", games)
+	}
+}
+
+func TestAddRecord(t *testing.T) {
+	record := othello.NewRecord(othello.New(othello.BLUE))
+	record.Moves = []othello.RecordMove{{Move: 34, Player: othello.BLUE}, {Move: 53, Player: othello.RED}}
+
+	if err := New().AddRecord(record, DEPTH); err == nil {
+		t.Errorf("unfinished game without a result should not be added")
+	}
+
+	record.Result = "-12.000:r"
+	b := New()
+	if err := b.AddRecord(record, DEPTH); err != nil {
+		t.Fatalf("game should be added but %v", err)
+	}
+
+	if moves := b.Moves(play(t, "d3")); len(moves) != 1 || moves[0].Wins != 1 {
+		t.Errorf("c5 should have won its game but %v", moves)
+	}
+}
+
+func TestLoadInvalidBook(t *testing.T) {
+	lines := []string{
+		"---------------------------OX------XO--------------------------- X d3 2",
+		"---------------------------OX------XO--------------------------- X z9 2 1",
+		"---------------------------OX------XO--------------------------- X d3 2 3",
+	}
+	for _, line := range lines {
+		if _, err := Load(bytes.NewBufferString(line)); err == nil {
+			t.Errorf("%q should not load", line)
+		}
+	}
+}
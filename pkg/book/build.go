@@ -0,0 +1,54 @@
+package book
+
+import (
+	"github.com/unathi-skosana/gothello/pkg/gomcts"
+	"github.com/unathi-skosana/gothello/pkg/othello"
+)
+
+// DEPTH - plies of a game added to a book by default
+const DEPTH = 20
+
+// AddRecord - count the first depth moves of a game record, which must be
+// over or have a result
+func (b *Book) AddRecord(record othello.GameRecord, depth int) error {
+	actions, err := record.Actions()
+	if err != nil {
+		return err
+	}
+
+	result, err := record.Winner()
+	if err != nil {
+		return err
+	}
+
+	var state gomcts.GameState = record.Start
+	for i := 0; i < depth && i < len(actions); i++ {
+		b.Add(state, actions[i], result)
+		state = actions[i].ApplyTo(state)
+	}
+	return nil
+}
+
+// SelfPlay - play games from the initial position with player taking both
+// sides, counting the first depth moves of each
+func (b *Book) SelfPlay(games, depth int, player gomcts.Player) {
+	for g := 0; g < games; g++ {
+		var state gomcts.GameState = othello.NewBitboard(othello.BLUE)
+		played := make([]gomcts.GameState, 0, depth)
+		actions := make([]othello.OthelloBoardGameAction, 0, depth)
+
+		for !state.IsGameEnded() {
+			action := player(state)
+			if len(actions) < depth {
+				played = append(played, state)
+				actions = append(actions, action.(othello.OthelloBoardGameAction))
+			}
+			state = action.ApplyTo(state)
+		}
+
+		result, _ := state.EvaluateGame()
+		for i := range actions {
+			b.Add(played[i], actions[i], result)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package gomcts_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/unathi-skosana/gothello/pkg/gomcts"
+	"github.com/unathi-skosana/gothello/pkg/othello"
+)
+
+func benchmarkSearch(b *testing.B, parallelism gomcts.Parallelism) {
+	for _, workers := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			options := gomcts.SearchOptions{MaxSimulations: 2000, Workers: workers, Parallelism: parallelism}
+			for i := 0; i < b.N; i++ {
+				gomcts.MonteCarloTreeSearchWithOptions(context.Background(), othello.New(othello.BLUE), othello.OthelloRandomRolloutPolicy, options)
+			}
+		})
+	}
+}
+
+func BenchmarkRootParallelSearch(b *testing.B) {
+	benchmarkSearch(b, gomcts.RootParallel)
+}
+
+func BenchmarkTreeParallelSearch(b *testing.B) {
+	benchmarkSearch(b, gomcts.TreeParallel)
+}
@@ -0,0 +1,98 @@
+package gomcts
+
+import (
+	"context"
+	"math"
+)
+
+// FinalMoveStrategy - rule choosing the action played once a search is over.
+// Whatever the strategy, an action proven won is always preferred and actions
+// proven lost are avoided while there are others.
+type FinalMoveStrategy int
+
+const (
+	// RobustChild - the most visited action, the default
+	RobustChild FinalMoveStrategy = iota
+	// MaxChild - the action with the highest mean payoff, fragile when some
+	// actions have only been visited a few times
+	MaxChild
+	// MaxRobustChild - the action that is both the most visited and has the
+	// highest mean payoff, searching past the budget until one does, bounded
+	// by SearchOptions.MaxRobustSimulations, and falling back to RobustChild
+	MaxRobustChild
+	// SecureChild - the action with the highest lower confidence bound on its
+	// mean payoff, mean - A/sqrt(visits) with A = SearchOptions.SecureConstant
+	SecureChild
+)
+
+// visitsPolicy - scores children by their number of visits
+type visitsPolicy struct{}
+
+func (visitsPolicy) Score(child ChildStatistics) float64 {
+	return child.Visits
+}
+
+// lowerBoundPolicy - scores children by a lower confidence bound on their
+// mean payoff
+type lowerBoundPolicy struct {
+	a float64
+}
+
+func (p lowerBoundPolicy) Score(child ChildStatistics) float64 {
+	return child.mean() - p.a/math.Sqrt(child.Visits)
+}
+
+// finalChild - child of node to play according to options.FinalMove
+func (node *monteCarloTreeSearchGameNode) finalChild(options SearchOptions) *monteCarloTreeSearchGameNode {
+	if best := node.bestProvenChild(); best != nil && best.provenWin() {
+		return best
+	}
+
+	switch options.FinalMove {
+	case RobustChild, MaxRobustChild:
+		return node.bestChild(visitsPolicy{})
+	case MaxChild:
+		return node.bestChild(UCB1{})
+	case SecureChild:
+		a := options.SecureConstant
+		if a == 0 {
+			a = 1
+		}
+		return node.bestChild(lowerBoundPolicy{a: a})
+	}
+
+	panic("gomcts: unknown final move strategy")
+}
+
+// searchUntilMaxRobust - keep searching from the roots in batches of a tenth
+// of the simulations run so far until the most visited child of root is also
+// the one with the highest mean payoff, or until the extension bound is
+// reached. The extension ignores the deadline of the search, it is bounded by
+// simulations only.
+func (s *Searcher) searchUntilMaxRobust(root *monteCarloTreeSearchGameNode, simulations int) (*monteCarloTreeSearchGameNode, int) {
+	limit := s.options.MaxRobustSimulations
+	if limit <= 0 {
+		limit = simulations / 2
+	}
+	batch := simulations / 10
+	if batch < 1 {
+		batch = 1
+	}
+
+	extra := 0
+	for extra < limit && !root.proven && root.bestChild(visitsPolicy{}) != root.bestChild(UCB1{}) {
+		options := s.options
+		options.Duration = 0
+		options.MinSimulations = 0
+		options.MaxSimulations = batch
+		if limit-extra < batch {
+			options.MaxSimulations = limit - extra
+		}
+
+		var n int
+		root, n = s.run(context.Background(), options)
+		extra += n
+	}
+
+	return root, simulations + extra
+}
@@ -0,0 +1,58 @@
+package gomcts
+
+import (
+	"context"
+	"testing"
+)
+
+// finalMoveRoot - root whose first child has the most visits and whose second
+// child has the highest mean payoff
+func finalMoveRoot() *monteCarloTreeSearchGameNode {
+	root := rootMCTSNode(nimGameState{stones: 40, nextToMove: 1})
+	root.n = 112
+	for i, stats := range [][2]float64{{100, 50}, {2, 2}, {10, 6}} {
+		child := newMCTSNode(&root, root.popFirstUntriedAction().ApplyTo(root.value), nimAction{take: i + 1})
+		child.n, child.q = stats[0], stats[1]
+		root.addChild(&child)
+	}
+	return &root
+}
+
+func TestFinalMoveStrategies(t *testing.T) {
+	root := finalMoveRoot()
+	expected := map[FinalMoveStrategy]int{RobustChild: 1, MaxChild: 2, SecureChild: 1}
+
+	for strategy, take := range expected {
+		child := root.finalChild(SearchOptions{FinalMove: strategy})
+		if child.causingAction.(nimAction).take != take {
+			t.Errorf("strategy %v should take %v stones but takes %v", strategy, take, child.causingAction.(nimAction).take)
+		}
+	}
+}
+
+func TestFinalMovePrefersProvenWin(t *testing.T) {
+	root := finalMoveRoot()
+	root.children[2].proven = true
+	root.children[2].result = GameResult(1)
+
+	for _, strategy := range []FinalMoveStrategy{RobustChild, MaxChild, SecureChild} {
+		if child := root.finalChild(SearchOptions{FinalMove: strategy}); child != root.children[2] {
+			t.Errorf("strategy %v should play the proven win", strategy)
+		}
+	}
+}
+
+func TestMaxRobustChildAgrees(t *testing.T) {
+	options := SearchOptions{MaxSimulations: 2000, FinalMove: MaxRobustChild, MaxRobustSimulations: 100000}
+	searcher := NewSearcher(nimGameState{stones: 30, nextToMove: 1}, nimRandomPolicy, options)
+	result := searcher.Analyze(context.Background())
+
+	root := searcher.roots[0]
+	if !root.proven && root.bestChild(visitsPolicy{}) != root.bestChild(UCB1{}) {
+		t.Errorf("most visited child and child with highest mean should agree after %v simulations", result.Simulations)
+	}
+
+	if result.Simulations < 2000 {
+		t.Errorf("search should run at least its budget of 2000 simulations but ran %v", result.Simulations)
+	}
+}
@@ -1,6 +1,8 @@
 package gomcts
 
-// GameResult - number representing a game result
+// GameResult - number representing a game result, the winning player or zero
+// for a draw. Players may be numbered any way but zero, 1 and 2 or 1 and -1
+// alike, as searches score results by comparing them to the player to move.
 type GameResult float64
 
 // Action - interface representing entity that can be applied to a game state (generating the next game state)
@@ -15,3 +17,22 @@ type GameState interface {
 	IsGameEnded() bool
 	NextToMove() int
 }
+
+// Player - picks the action to play in a game state, a search or any other
+// way of playing a game
+type Player func(state GameState) Action
+
+// Hashable - optional GameState extension, equal states must have equal
+// hashes. Searches use it to share statistics between transpositions.
+type Hashable interface {
+	GameState
+	Hash() uint64
+}
+
+// KeyedAction - optional Action extension, actions placing the same move
+// must have equal keys whatever the state they are applied to. RAVE uses it
+// to recognise a move played at different points of a game.
+type KeyedAction interface {
+	Action
+	ActionKey() uint64
+}
@@ -1,10 +1,15 @@
 package gomcts
 
 import (
+	"context"
 	"math"
+	"math/rand"
+	"time"
 )
 
-type RolloutPolicy func(GameState) Action
+// RolloutPolicy - picks the action played at each step of a rollout, drawing
+// any randomness it needs from the source given
+type RolloutPolicy func(GameState, *rand.Rand) Action
 
 type monteCarloTreeSearchGameNode struct {
 	parent         *monteCarloTreeSearchGameNode
@@ -12,27 +17,114 @@ type monteCarloTreeSearchGameNode struct {
 	value          GameState
 	untriedActions []Action
 	causingAction  Action
-	q              float64
-	n              float64
+	// statistics of the node, shared with transpositions of its state when
+	// the search uses a transposition table
+	*nodeStatistics
+	// prior - prior probability of causingAction, priors - prior
+	// probabilities of the legal actions, see PriorPolicy
+	prior  float64
+	priors []float64
+	// amafQ, amafN - all moves as first statistics of causingAction, see rave.go
+	amafQ float64
+	amafN float64
+	// proven - whether result is the outcome of the game under perfect play
+	// from this node on, see solver.go
+	proven bool
+	result GameResult
+	// table - transposition table of the tree, set on roots only
+	table *transpositionTable
+}
+
+type nodeStatistics struct {
+	q float64
+	n float64
+	// q2 - sum of squared payoffs, see UCB1Tuned
+	q2 float64
 }
 
 // MonteCarloTreeSearch - function starting Monte Carlo Tree Search over provided GameState using RolloutPolicy of your choice, repeating simulation requested amount of time
 func MonteCarloTreeSearch(state GameState, rolloutPolicy RolloutPolicy, simulations int) Action {
-	root := newMCTSNode(nil, state, nil)
-	var leaf *monteCarloTreeSearchGameNode
-	for i := 0; i < simulations; i++ {
-		leaf = root.treePolicy()
-		result := leaf.rollout(rolloutPolicy)
-		leaf.backpropagate(result)
+	return MonteCarloTreeSearchWithOptions(context.Background(), state, rolloutPolicy, SearchOptions{MaxSimulations: simulations})
+}
+
+// SearchOptions - bounds of a search. A search stops once MaxSimulations
+// simulations have been run or once its deadline expires, whichever comes
+// first, but never before MinSimulations simulations have been run. It stops
+// early regardless once the outcome of the root is proven.
+type SearchOptions struct {
+	// Duration - wall clock budget of the search, zero for none
+	Duration time.Duration
+	// MinSimulations - number of simulations run even if the deadline has expired
+	MinSimulations int
+	// MaxSimulations - upper bound on the number of simulations, zero for none
+	MaxSimulations int
+	// Workers - number of goroutines searching in parallel, zero or one for
+	// a sequential search. Simulation bounds apply to all workers together.
+	Workers int
+	// Parallelism - how workers share the search when Workers is above one
+	Parallelism Parallelism
+	// Selection - policy scoring children while descending the tree, UCB1
+	// with C = 1.4 when nil
+	Selection SelectionPolicy
+	// FinalMove - rule choosing the action played once the search is over,
+	// RobustChild by default
+	FinalMove FinalMoveStrategy
+	// MaxRobustSimulations - bound on the simulations a MaxRobustChild
+	// search runs beyond its budget, half the simulations run when zero
+	MaxRobustSimulations int
+	// SecureConstant - weight A of the confidence term of SecureChild, 1 when zero
+	SecureConstant float64
+	// TranspositionTableSize - number of states whose statistics are shared
+	// between transpositions, zero to search a plain tree. Only used when
+	// states implement Hashable.
+	TranspositionTableSize int
+	// Replacement - how a full transposition table makes room for new states
+	Replacement ReplacementScheme
+	// Seed - seed of the random sources handed to the rollout policy, worker
+	// w of a parallel search uses Seed + w. Searches bounded by simulations
+	// only are reproducible, tree parallel searches excepted.
+	Seed int64
+}
+
+// MonteCarloTreeSearchWithOptions - function starting Monte Carlo Tree Search over provided GameState using RolloutPolicy of your choice, searching until ctx is done, options.Duration elapses or the simulation bounds of options are reached
+func MonteCarloTreeSearchWithOptions(ctx context.Context, state GameState, rolloutPolicy RolloutPolicy, options SearchOptions) Action {
+	return NewSearcher(state, rolloutPolicy, options).Search(ctx)
+}
+
+// MonteCarloTreeSearchTimed - function starting Monte Carlo Tree Search over provided GameState using RolloutPolicy of your choice, searching until budget elapses
+func MonteCarloTreeSearchTimed(state GameState, rolloutPolicy RolloutPolicy, budget time.Duration) Action {
+	return MonteCarloTreeSearchWithOptions(context.Background(), state, rolloutPolicy, SearchOptions{Duration: budget})
+}
+
+// search - run simulations from node until ctx is done or the bounds of
+// options are reached or node is proven, returning the number of simulations
+// run. At least one simulation is always run so that the root has a child to
+// choose.
+func (node *monteCarloTreeSearchGameNode) search(ctx context.Context, rolloutPolicy RolloutPolicy, options SearchOptions, rng *rand.Rand) int {
+	simulations := 0
+	for !node.proven && !options.stop(ctx, simulations) {
+		leaf := node.treePolicy(options.selection(), node.table)
+		result, moves := rollout(leaf.value, leaf.proven, leaf.result, rolloutPolicy, rng, recordsAMAF(options.selection()))
+		leaf.backpropagate(result, moves)
+		simulations++
 	}
 
-	return root.uctBestChild(0.0).causingAction
+	return simulations
+}
+
+// stop - whether a search that has run the given number of simulations is over
+func (options SearchOptions) stop(ctx context.Context, simulations int) bool {
+	if options.MaxSimulations > 0 && simulations >= options.MaxSimulations {
+		return true
+	}
+	return simulations >= options.MinSimulations && simulations >= 1 && ctx.Err() != nil
 }
 
 func newMCTSNode(parentNode *monteCarloTreeSearchGameNode, state GameState, causingAction Action) monteCarloTreeSearchGameNode {
-	node := monteCarloTreeSearchGameNode{parent: parentNode, value: state, causingAction: causingAction}
+	node := monteCarloTreeSearchGameNode{parent: parentNode, value: state, causingAction: causingAction, nodeStatistics: &nodeStatistics{}}
 	node.children = make([]*monteCarloTreeSearchGameNode, 0, 0)
 	node.untriedActions = state.GetLegalActions()
+	node.result, node.proven = state.EvaluateGame()
 	return node
 }
 
@@ -40,12 +132,17 @@ func rootMCTSNode(state GameState) monteCarloTreeSearchGameNode {
 	return newMCTSNode(nil, state, nil)
 }
 
-func (node *monteCarloTreeSearchGameNode) uctBestChild(c float64) *monteCarloTreeSearchGameNode {
+// bestChild - child with the highest score under policy, leaving out children
+// proven lost for the player to move unless there is nothing else
+func (node *monteCarloTreeSearchGameNode) bestChild(policy SelectionPolicy) *monteCarloTreeSearchGameNode {
 	chosenIndex := 0
 	maxValue := -math.MaxFloat64
 	for i, child := range node.children {
-		if (child.q/child.n)+c*math.Sqrt(2*math.Log(node.n)/child.n) > maxValue {
-			maxValue = (child.q / child.n) + c*math.Sqrt(2*math.Log(node.n)/child.n)
+		if child.provenLoss() && !node.provenLost() {
+			continue
+		}
+		if score := child.score(policy); score > maxValue {
+			maxValue = score
 			chosenIndex = i
 		}
 	}
@@ -53,27 +150,76 @@ func (node *monteCarloTreeSearchGameNode) uctBestChild(c float64) *monteCarloTre
 	return node.children[chosenIndex]
 }
 
-func (node *monteCarloTreeSearchGameNode) rollout(policy RolloutPolicy) GameResult {
-	currentState := node.value
+// rollout - play the game out from state with policy drawing from rng,
+// returning its result and, when record is set, the moves played on the way,
+// see rave.go. The result of a proven state is returned as is. It never
+// touches the tree, so tree parallel workers run it without the lock.
+func rollout(state GameState, proven bool, result GameResult, policy RolloutPolicy, rng *rand.Rand, record bool) (GameResult, []amafMove) {
+	var moves []amafMove
+	if record {
+		moves = make([]amafMove, 0)
+	}
+
+	if proven {
+		return result, moves
+	}
+
+	currentState := state
 	for !currentState.IsGameEnded() {
-		currentState = policy(currentState).ApplyTo(currentState)
+		action := policy(currentState, rng)
+		if record {
+			moves = append(moves, newAMAFMove(currentState, action))
+		}
+		currentState = action.ApplyTo(currentState)
 	}
 	gameResult, _ := currentState.EvaluateGame()
-	return gameResult
+	return gameResult, moves
 }
 
-func (node *monteCarloTreeSearchGameNode) backpropagate(result GameResult) {
+// backpropagate - add result to the statistics of node and its ancestors and
+// propagate proofs upwards. When moves played by the rollout are given, the
+// AMAF statistics along the way are updated as well.
+func (node *monteCarloTreeSearchGameNode) backpropagate(result GameResult, moves []amafMove) {
+	var played map[amafMove]bool
+	if moves != nil {
+		played = make(map[amafMove]bool, len(moves))
+		for _, move := range moves {
+			played[move] = true
+		}
+	}
+
+	proving := node.proven
 	for !node.isRoot() {
-		node.q += float64(result) * float64(node.parent.value.NextToMove())
+		if played != nil {
+			node.updateAMAF(result, played)
+			played[newAMAFMove(node.parent.value, node.causingAction)] = true
+		}
+
+		payoff := reward(result, node.parent.value.NextToMove())
+		node.q += payoff
+		node.q2 += payoff * payoff
 		node.n++
 		node = node.parent
+		proving = proving && node.prove()
+	}
+	if played != nil {
+		node.updateAMAF(result, played)
 	}
 	node.n++
 }
 
-func (node *monteCarloTreeSearchGameNode) isTerminal() bool {
-	_, ended := node.value.EvaluateGame()
-	return ended
+// reward - payoff of result for player, 1 for a win, -1 for a loss and 0 for
+// a draw. Payoffs used to be the result times the player, which only holds
+// when players are numbered 1 and -1: with players 1 and 2, as in Othello,
+// player 1 scored a loss twice a win and player 2 a loss half a win.
+func reward(result GameResult, player int) float64 {
+	switch result {
+	case GameResult(player):
+		return 1
+	case 0:
+		return 0
+	}
+	return -1
 }
 
 func (node *monteCarloTreeSearchGameNode) isFullyExpanded() bool {
@@ -86,19 +232,31 @@ func (node *monteCarloTreeSearchGameNode) popFirstUntriedAction() Action {
 	return action
 }
 
-func (node *monteCarloTreeSearchGameNode) expand() *monteCarloTreeSearchGameNode {
+func (node *monteCarloTreeSearchGameNode) expand(policy SelectionPolicy, table *transpositionTable) *monteCarloTreeSearchGameNode {
+	prior := 0.0
+	if priors, ok := policy.(PriorPolicy); ok {
+		if len(node.children) == 0 {
+			node.priors = priors.Priors(node.value, node.untriedActions)
+		}
+		prior = node.priors[len(node.children)]
+	}
+
 	action := node.popFirstUntriedAction()
 	expandedChild := newMCTSNode(node, action.ApplyTo(node.value), action)
+	expandedChild.prior = prior
+	if table != nil {
+		expandedChild.nodeStatistics = table.statistics(&expandedChild)
+	}
 	node.addChild(&expandedChild)
 	return &expandedChild
 }
 
-func (node *monteCarloTreeSearchGameNode) treePolicy() *monteCarloTreeSearchGameNode {
-	for !node.isTerminal() {
+func (node *monteCarloTreeSearchGameNode) treePolicy(policy SelectionPolicy, table *transpositionTable) *monteCarloTreeSearchGameNode {
+	for !node.proven {
 		if !node.isFullyExpanded() {
-			return node.expand()
+			return node.expand(policy, table)
 		}
-		node = node.uctBestChild(1.4)
+		node = node.bestChild(policy)
 	}
 	return node
 }
@@ -0,0 +1,168 @@
+package gomcts
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// nimGameState - pile of stones from which players 1 and 2 alternately take
+// one to three stones, whoever takes the last stone wins. Signed games number
+// player 2 as -1 outside.
+type nimGameState struct {
+	stones     int
+	nextToMove int
+	signed     bool
+}
+
+type nimAction struct {
+	take int
+}
+
+func (a nimAction) ApplyTo(s GameState) GameState {
+	g := s.(nimGameState)
+	return nimGameState{stones: g.stones - a.take, nextToMove: 3 - g.nextToMove, signed: g.signed}
+}
+
+func (a nimAction) ActionKey() uint64 {
+	return uint64(a.take)
+}
+
+func (s nimGameState) EvaluateGame() (GameResult, bool) {
+	if s.stones == 0 {
+		return GameResult(s.player(3 - s.nextToMove)), true
+	}
+	return GameResult(0), false
+}
+
+func (s nimGameState) GetLegalActions() []Action {
+	actions := make([]Action, 0, 3)
+	for take := 1; take <= 3 && take <= s.stones; take++ {
+		actions = append(actions, nimAction{take: take})
+	}
+	return actions
+}
+
+func (s nimGameState) IsGameEnded() bool {
+	return s.stones == 0
+}
+
+func (s nimGameState) NextToMove() int {
+	return s.player(s.nextToMove)
+}
+
+// player - number of player p outside the game
+func (s nimGameState) player(p int) int {
+	if s.signed && p == 2 {
+		return -1
+	}
+	return p
+}
+
+func (s nimGameState) Hash() uint64 {
+	return uint64(s.stones)<<2 | uint64(s.nextToMove)
+}
+
+func nimFirstActionPolicy(s GameState, rng *rand.Rand) Action {
+	return s.GetLegalActions()[0]
+}
+
+func TestSearchStopsAtMaxSimulations(t *testing.T) {
+	root := rootMCTSNode(nimGameState{stones: 12, nextToMove: 1})
+	simulations := root.search(context.Background(), nimFirstActionPolicy, SearchOptions{MaxSimulations: 250}, rand.New(rand.NewSource(1)))
+
+	if simulations != 250 {
+		t.Errorf("search should run 250 simulations but ran %v", simulations)
+	}
+
+	if root.n != 250 {
+		t.Errorf("root should be visited 250 times but was visited %v times", root.n)
+	}
+}
+
+func TestSearchRunsMinSimulationsAfterDeadline(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	root := rootMCTSNode(nimGameState{stones: 12, nextToMove: 1})
+	simulations := root.search(ctx, nimFirstActionPolicy, SearchOptions{MinSimulations: 40}, rand.New(rand.NewSource(1)))
+
+	if simulations != 40 {
+		t.Errorf("search should run 40 simulations but ran %v", simulations)
+	}
+}
+
+func TestTimedSearchRespectsBudget(t *testing.T) {
+	start := time.Now()
+	action := MonteCarloTreeSearchTimed(nimGameState{stones: 30, nextToMove: 1}, nimFirstActionPolicy, 50*time.Millisecond)
+	elapsed := time.Since(start)
+
+	if action == nil {
+		t.Errorf("timed search should return an action")
+	}
+
+	if elapsed > time.Second {
+		t.Errorf("search with a 50ms budget took %v", elapsed)
+	}
+}
+
+func TestUnboundedSearchPanics(t *testing.T) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Errorf("The code did not panic but should")
+		}
+	}()
+
+	MonteCarloTreeSearchWithOptions(context.Background(), nimGameState{stones: 5, nextToMove: 1}, nimFirstActionPolicy, SearchOptions{})
+}
+
+func nimRandomPolicy(s GameState, rng *rand.Rand) Action {
+	actions := s.GetLegalActions()
+	return actions[rng.Intn(len(actions))]
+}
+
+func TestSearchFindsWinningMove(t *testing.T) {
+	// taking one stone leaves a multiple of four, which wins
+	for _, player := range []int{1, 2} {
+		state := nimGameState{stones: 9, nextToMove: player}
+		action := MonteCarloTreeSearch(state, nimRandomPolicy, 3000)
+
+		if action.(nimAction).take != 1 {
+			t.Errorf("player %v should take 1 stone but takes %v", player, action.(nimAction).take)
+		}
+	}
+}
+
+func TestSearchFindsForcedWinForEitherNumbering(t *testing.T) {
+	// taking two stones leaves a multiple of four, which wins
+	for _, signed := range []bool{false, true} {
+		for _, player := range []int{1, 2} {
+			state := nimGameState{stones: 6, nextToMove: player, signed: signed}
+			action := MonteCarloTreeSearch(state, nimRandomPolicy, 3000)
+
+			if action.(nimAction).take != 2 {
+				t.Errorf("player %v should take 2 stones but takes %v", state.NextToMove(), action.(nimAction).take)
+			}
+		}
+	}
+}
+
+func TestReward(t *testing.T) {
+	if reward(GameResult(1), 1) != 1 {
+		t.Errorf("a win should be worth 1 but is worth %v", reward(GameResult(1), 1))
+	}
+
+	if reward(GameResult(2), 1) != -1 {
+		t.Errorf("a loss should be worth -1 but is worth %v", reward(GameResult(2), 1))
+	}
+
+	if reward(GameResult(-1), 1) != -1 || reward(GameResult(-1), -1) != 1 {
+		t.Errorf("results of player -1 should be worth -1 to player 1 and 1 to player -1")
+	}
+
+	if reward(GameResult(0), 2) != 0 {
+		t.Errorf("a draw should be worth 0 but is worth %v", reward(GameResult(0), 2))
+	}
+}
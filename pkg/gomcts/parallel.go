@@ -0,0 +1,156 @@
+package gomcts
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+)
+
+// Parallelism - strategy used to spread a search over several goroutines
+type Parallelism int
+
+const (
+	// RootParallel - every worker grows its own tree from the root, the
+	// statistics of the root children are merged once all workers are done
+	RootParallel Parallelism = iota
+	// TreeParallel - workers grow one shared tree, guarded by a lock, and use
+	// virtual loss to steer each other towards different lines
+	TreeParallel
+)
+
+// virtualLoss - lost simulations added to nodes a worker is currently
+// simulating through
+const virtualLoss = 1.0
+
+// rootParallelSearch - grow each of roots on its own goroutine with the
+// random source of the same index, splitting the bounds of options between
+// them, and merge their root statistics
+func rootParallelSearch(ctx context.Context, roots []*monteCarloTreeSearchGameNode, rolloutPolicy RolloutPolicy, options SearchOptions, rngs []*rand.Rand) (*monteCarloTreeSearchGameNode, int) {
+	simulations := make([]int, len(roots))
+
+	var wg sync.WaitGroup
+	for w := range roots {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			simulations[w] = roots[w].search(ctx, rolloutPolicy, options.share(w), rngs[w])
+		}(w)
+	}
+	wg.Wait()
+
+	total := 0
+	for w := range roots {
+		total += simulations[w]
+	}
+
+	return mergeRoots(roots), total
+}
+
+// share - bounds of worker w when the bounds of options are split evenly
+// between options.Workers workers
+func (options SearchOptions) share(w int) SearchOptions {
+	split := func(total int) int {
+		part := total / options.Workers
+		if w < total%options.Workers {
+			part++
+		}
+		return part
+	}
+
+	shared := options
+	shared.MinSimulations = split(options.MinSimulations)
+	if options.MaxSimulations > 0 {
+		// a worker left without simulations still runs one
+		shared.MaxSimulations = split(options.MaxSimulations)
+		if shared.MaxSimulations == 0 {
+			shared.MaxSimulations = 1
+		}
+	}
+	shared.Workers = 1
+	return shared
+}
+
+// mergeRoots - node summing the root statistics of roots, which must all
+// have been grown from the same state. Children are matched by the order in
+// which they were expanded, which follows the order of GetLegalActions. The
+// roots themselves are left untouched so their trees can be searched further.
+func mergeRoots(roots []*monteCarloTreeSearchGameNode) *monteCarloTreeSearchGameNode {
+	merged := &monteCarloTreeSearchGameNode{value: roots[0].value, nodeStatistics: &nodeStatistics{}}
+	for _, root := range roots {
+		merged.n += root.n
+		for i, child := range root.children {
+			if i == len(merged.children) {
+				merged.addChild(&monteCarloTreeSearchGameNode{parent: merged, value: child.value, causingAction: child.causingAction, prior: child.prior, nodeStatistics: &nodeStatistics{}})
+			}
+			merged.children[i].q += child.q
+			merged.children[i].q2 += child.q2
+			merged.children[i].amafQ += child.amafQ
+			merged.children[i].amafN += child.amafN
+			merged.children[i].n += child.n
+			if child.proven {
+				merged.children[i].proven = true
+				merged.children[i].result = child.result
+			}
+		}
+	}
+	return merged
+}
+
+// sharedSearch - run simulations from node on a goroutine per random source
+// of rngs, sharing the tree until the bounds of options are reached or node
+// is proven, returning the total number of simulations run
+func (node *monteCarloTreeSearchGameNode) sharedSearch(ctx context.Context, rolloutPolicy RolloutPolicy, options SearchOptions, rngs []*rand.Rand) int {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	simulations := 0
+
+	for _, rng := range rngs {
+		wg.Add(1)
+		go func(rng *rand.Rand) {
+			defer wg.Done()
+			for {
+				mu.Lock()
+				if node.proven || options.stop(ctx, simulations) {
+					mu.Unlock()
+					return
+				}
+				simulations++
+				leaf := node.treePolicy(options.selection(), node.table)
+				leaf.addVirtualLoss()
+				// other workers may prove the leaf while this one plays out
+				// its rollout, so what the rollout needs is read under the lock
+				state, proven, provenResult := leaf.value, leaf.proven, leaf.result
+				mu.Unlock()
+
+				result, moves := rollout(state, proven, provenResult, rolloutPolicy, rng, recordsAMAF(options.selection()))
+
+				mu.Lock()
+				leaf.removeVirtualLoss()
+				leaf.backpropagate(result, moves)
+				mu.Unlock()
+			}
+		}(rng)
+	}
+	wg.Wait()
+
+	return simulations
+}
+
+// addVirtualLoss - make the path from the root to node look less attractive
+// to other workers until its simulation is backpropagated
+func (node *monteCarloTreeSearchGameNode) addVirtualLoss() {
+	for ; node != nil; node = node.parent {
+		node.q -= virtualLoss
+		node.q2 += virtualLoss
+		node.n += virtualLoss
+	}
+}
+
+// removeVirtualLoss - undo addVirtualLoss
+func (node *monteCarloTreeSearchGameNode) removeVirtualLoss() {
+	for ; node != nil; node = node.parent {
+		node.q += virtualLoss
+		node.q2 -= virtualLoss
+		node.n -= virtualLoss
+	}
+}
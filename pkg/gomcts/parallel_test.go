@@ -0,0 +1,77 @@
+package gomcts
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestRootParallelSearchSplitsSimulations(t *testing.T) {
+	options := SearchOptions{MaxSimulations: 301, Workers: 4, Parallelism: RootParallel}
+	root, simulations := NewSearcher(nimGameState{stones: 40, nextToMove: 1}, nimFirstActionPolicy, options).run(context.Background(), options)
+
+	if simulations != 301 {
+		t.Errorf("search should run 301 simulations but ran %v", simulations)
+	}
+
+	if root.n != 301 {
+		t.Errorf("merged root should be visited 301 times but was visited %v times", root.n)
+	}
+
+	visits := 0.0
+	for _, child := range root.children {
+		visits += child.n
+	}
+	if visits != 301 {
+		t.Errorf("merged children should be visited 301 times but were visited %v times", visits)
+	}
+}
+
+func TestTreeParallelSearchBacksOutVirtualLoss(t *testing.T) {
+	options := SearchOptions{MaxSimulations: 500, Workers: 4, Parallelism: TreeParallel}
+	root, simulations := NewSearcher(nimGameState{stones: 40, nextToMove: 1}, nimFirstActionPolicy, options).run(context.Background(), options)
+
+	if simulations != 500 {
+		t.Errorf("search should run 500 simulations but ran %v", simulations)
+	}
+
+	if root.n != 500 {
+		t.Errorf("root should be visited 500 times but was visited %v times", root.n)
+	}
+}
+
+func TestParallelSearchFindsWinningMove(t *testing.T) {
+	// taking one stone leaves a multiple of four, which wins
+	state := nimGameState{stones: 9, nextToMove: 1}
+
+	for _, parallelism := range []Parallelism{RootParallel, TreeParallel} {
+		options := SearchOptions{MaxSimulations: 4000, Workers: 4, Parallelism: parallelism}
+		action := MonteCarloTreeSearchWithOptions(context.Background(), state, nimRandomPolicy, options)
+
+		if action.(nimAction).take != 1 {
+			t.Errorf("parallelism %v should take 1 stone but takes %v", parallelism, action.(nimAction).take)
+		}
+	}
+}
+
+func TestTreeParallelSolverRace(t *testing.T) {
+	// slow rollouts keep workers outside the lock while others prove the
+	// nodes they play out from, run with -race to catch shared reads
+	slowPolicy := func(s GameState, rng *rand.Rand) Action {
+		time.Sleep(10 * time.Microsecond)
+		return nimRandomPolicy(s, rng)
+	}
+
+	state := nimGameState{stones: 9, nextToMove: 1}
+	options := SearchOptions{MaxSimulations: 20000, Workers: 8, Parallelism: TreeParallel}
+	result := MonteCarloTreeSearchAnalysis(context.Background(), state, slowPolicy, options)
+
+	if !result.Proven || result.Result != GameResult(1) {
+		t.Errorf("9 stones should be proven won for player 1 but proven is %v with result %v", result.Proven, result.Result)
+	}
+
+	if result.Action.(nimAction).take != 1 {
+		t.Errorf("player 1 should take 1 stone but takes %v", result.Action.(nimAction).take)
+	}
+}
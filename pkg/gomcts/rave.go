@@ -0,0 +1,64 @@
+package gomcts
+
+import (
+	"math"
+)
+
+// Rapid Action Value Estimation: besides its own statistics, every child
+// keeps all moves as first (AMAF) statistics, the payoffs of all simulations
+// through its parent in which its action was played at any later point by
+// the same player. These accumulate much faster than the child's own
+// statistics and are blended in while the child has few visits of its own.
+
+// RAVE - UCB1 selection on a blend of the mean payoff and the AMAF mean
+// payoff of a child. The AMAF mean is weighted by
+// sqrt(Equivalence / (3*visits + Equivalence)), so Equivalence is about the
+// number of visits at which both means count the same. Actions must
+// implement KeyedAction.
+type RAVE struct {
+	C           float64
+	Equivalence float64
+}
+
+// Score - RAVE implementation of Score method of SelectionPolicy interface
+func (p RAVE) Score(child ChildStatistics) float64 {
+	mean := child.mean()
+	if child.AMAFVisits > 0 {
+		beta := math.Sqrt(p.Equivalence / (3*child.Visits + p.Equivalence))
+		mean = (1-beta)*mean + beta*child.AMAFValue/child.AMAFVisits
+	}
+	return mean + p.C*math.Sqrt(2*math.Log(child.ParentVisits)/child.Visits)
+}
+
+// amafMove - move identified by the player making it and its action key
+type amafMove struct {
+	player int
+	key    uint64
+}
+
+// newAMAFMove - action as played in state
+func newAMAFMove(state GameState, action Action) amafMove {
+	keyed, ok := action.(KeyedAction)
+	if !ok {
+		panic("gomcts: RAVE needs actions implementing KeyedAction")
+	}
+	return amafMove{player: state.NextToMove(), key: keyed.ActionKey()}
+}
+
+// recordsAMAF - whether searches with policy need AMAF statistics
+func recordsAMAF(policy SelectionPolicy) bool {
+	_, ok := policy.(RAVE)
+	return ok
+}
+
+// updateAMAF - add result to the AMAF statistics of the children of node
+// whose action was played by the player to move at node
+func (node *monteCarloTreeSearchGameNode) updateAMAF(result GameResult, played map[amafMove]bool) {
+	player := node.value.NextToMove()
+	for _, child := range node.children {
+		if played[newAMAFMove(node.value, child.causingAction)] {
+			child.amafQ += reward(result, player)
+			child.amafN++
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package gomcts
+
+import (
+	"context"
+	"testing"
+)
+
+func TestBackpropagateUpdatesAMAF(t *testing.T) {
+	root := rootMCTSNode(nimGameState{stones: 20, nextToMove: 1})
+	takeOne := root.expand(UCB1{}, nil)
+	takeTwo := root.expand(UCB1{}, nil)
+	takeThree := root.expand(UCB1{}, nil)
+
+	// player 1 takes 1, player 2 takes 1, the rollout has player 1 take 2
+	// and player 2 take 3, player 1 wins
+	leaf := takeOne.expand(UCB1{}, nil)
+	moves := []amafMove{{player: 1, key: 2}, {player: 2, key: 3}}
+	leaf.backpropagate(GameResult(1), moves)
+
+	if takeOne.amafN != 1 || takeOne.amafQ != 1 {
+		t.Errorf("taking 1 stone was played by player 1 and should be credited a win but has %v of %v", takeOne.amafQ, takeOne.amafN)
+	}
+
+	if takeTwo.amafN != 1 || takeTwo.amafQ != 1 {
+		t.Errorf("taking 2 stones was played later by player 1 and should be credited a win but has %v of %v", takeTwo.amafQ, takeTwo.amafN)
+	}
+
+	if takeThree.amafN != 0 {
+		t.Errorf("taking 3 stones was only played by player 2 and should not be credited to player 1")
+	}
+}
+
+func TestRAVEBlendsTowardsAMAF(t *testing.T) {
+	policy := RAVE{C: 0, Equivalence: 100}
+	fresh := ChildStatistics{Visits: 1, ParentVisits: 10, Value: -1, AMAFVisits: 50, AMAFValue: 50}
+	seasoned := ChildStatistics{Visits: 10000, ParentVisits: 20000, Value: -10000, AMAFVisits: 50, AMAFValue: 50}
+
+	if policy.Score(fresh) <= 0 {
+		t.Errorf("a child with few visits should mostly be scored by its AMAF mean but scores %v", policy.Score(fresh))
+	}
+
+	if policy.Score(seasoned) >= -0.8 {
+		t.Errorf("a child with many visits should mostly be scored by its own mean but scores %v", policy.Score(seasoned))
+	}
+}
+
+func TestRAVESearchFindsWinningMove(t *testing.T) {
+	options := SearchOptions{MaxSimulations: 3000, Selection: RAVE{C: 0.7, Equivalence: 300}}
+	action := MonteCarloTreeSearchWithOptions(context.Background(), nimGameState{stones: 9, nextToMove: 1}, nimRandomPolicy, options)
+
+	if action.(nimAction).take != 1 {
+		t.Errorf("player 1 should take 1 stone but takes %v", action.(nimAction).take)
+	}
+}
@@ -0,0 +1,77 @@
+package gomcts
+
+import (
+	"context"
+	"time"
+)
+
+// ActionStatistics - statistics gathered for one action at the root of a search
+type ActionStatistics struct {
+	Action Action
+	// Visits - number of simulations that went through the action
+	Visits int
+	// WinRate - mean payoff of the action for the player to move at the
+	// root, scaled to [0, 1] with draws counting as half a win
+	WinRate float64
+	// UCT - score of the action under the search's selection policy when the
+	// search stopped
+	UCT float64
+	// Proven - whether the outcome of the action is known, see Result
+	Proven bool
+	// Result - result of the game under perfect play after the action, only
+	// meaningful when Proven
+	Result GameResult
+}
+
+// SearchResult - outcome of a search
+type SearchResult struct {
+	// Action - action chosen by the search
+	Action Action
+	// Proven - whether the outcome of Action is known, see Result
+	Proven bool
+	// Result - result of the game under perfect play after Action, only
+	// meaningful when Proven
+	Result GameResult
+	// Actions - statistics of every expanded root action, in the order of GetLegalActions
+	Actions []ActionStatistics
+	// PrincipalVariation - line of play the search expects, starting with
+	// Action and following the most visited children below it
+	PrincipalVariation []Action
+	// Simulations - number of simulations run by the search, not counting
+	// those inherited from earlier searches of a Searcher
+	Simulations int
+	// Elapsed - wall clock time spent searching
+	Elapsed time.Duration
+}
+
+// MonteCarloTreeSearchAnalysis - function starting Monte Carlo Tree Search like MonteCarloTreeSearchWithOptions, reporting the statistics behind the action chosen
+func MonteCarloTreeSearchAnalysis(ctx context.Context, state GameState, rolloutPolicy RolloutPolicy, options SearchOptions) SearchResult {
+	return NewSearcher(state, rolloutPolicy, options).Analyze(ctx)
+}
+
+// statistics - statistics of the children of node, scored by policy
+func (node *monteCarloTreeSearchGameNode) statistics(policy SelectionPolicy) []ActionStatistics {
+	statistics := make([]ActionStatistics, len(node.children))
+	for i, child := range node.children {
+		statistics[i] = ActionStatistics{
+			Action:  child.causingAction,
+			Visits:  int(child.n),
+			WinRate: (child.q/child.n + 1) / 2,
+			UCT:     child.score(policy),
+			Proven:  child.proven,
+			Result:  child.result,
+		}
+	}
+	return statistics
+}
+
+// mostVisitedChild - child of node with the most visits, nil for a leaf
+func (node *monteCarloTreeSearchGameNode) mostVisitedChild() *monteCarloTreeSearchGameNode {
+	var best *monteCarloTreeSearchGameNode
+	for _, child := range node.children {
+		if best == nil || child.n > best.n {
+			best = child
+		}
+	}
+	return best
+}
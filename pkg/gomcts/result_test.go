@@ -0,0 +1,54 @@
+package gomcts
+
+import (
+	"context"
+	"testing"
+)
+
+func TestAnalysisReportsRootStatistics(t *testing.T) {
+	state := nimGameState{stones: 21, nextToMove: 1}
+	result := MonteCarloTreeSearchAnalysis(context.Background(), state, nimRandomPolicy, SearchOptions{MaxSimulations: 3000})
+
+	if result.Simulations != 3000 {
+		t.Errorf("search should run 3000 simulations but ran %v", result.Simulations)
+	}
+
+	if len(result.Actions) != 3 {
+		t.Fatalf("there should be statistics for 3 actions but there are %v", len(result.Actions))
+	}
+
+	visits := 0
+	for _, statistics := range result.Actions {
+		visits += statistics.Visits
+		if statistics.WinRate < 0 || statistics.WinRate > 1 {
+			t.Errorf("win rate should be within [0, 1] but is %v", statistics.WinRate)
+		}
+	}
+	if visits != 3000 {
+		t.Errorf("root actions should be visited 3000 times but were visited %v times", visits)
+	}
+
+}
+
+func TestAnalysisPrincipalVariationStartsWithAction(t *testing.T) {
+	for _, workers := range []int{1, 4} {
+		options := SearchOptions{MaxSimulations: 2000, Workers: workers}
+		result := MonteCarloTreeSearchAnalysis(context.Background(), nimGameState{stones: 9, nextToMove: 1}, nimRandomPolicy, options)
+
+		if len(result.PrincipalVariation) < 2 {
+			t.Fatalf("principal variation should be at least 2 actions long but is %v", result.PrincipalVariation)
+		}
+
+		if result.PrincipalVariation[0] != result.Action {
+			t.Errorf("principal variation should start with %v but starts with %v", result.Action, result.PrincipalVariation[0])
+		}
+
+		stones := 9
+		for _, action := range result.PrincipalVariation {
+			stones -= action.(nimAction).take
+		}
+		if stones < 0 {
+			t.Errorf("principal variation %v takes more stones than there are", result.PrincipalVariation)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package gomcts
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// Searcher - Monte Carlo Tree Search keeping its tree between moves. Once the
+// actions actually played are passed to Advance, the statistics gathered for
+// the position reached carry over to the next search.
+type Searcher struct {
+	roots         []*monteCarloTreeSearchGameNode
+	rngs          []*rand.Rand
+	rolloutPolicy RolloutPolicy
+	options       SearchOptions
+}
+
+// NewSearcher - initializes a Searcher over provided GameState using RolloutPolicy of your choice, bounding every search by options
+func NewSearcher(state GameState, rolloutPolicy RolloutPolicy, options SearchOptions) *Searcher {
+	searcher := &Searcher{rolloutPolicy: rolloutPolicy, options: options}
+	searcher.Reset(state)
+	return searcher
+}
+
+// Reset - discard the tree and start over from state, reseeding the random
+// sources of the rollouts
+func (s *Searcher) Reset(state GameState) {
+	trees := 1
+	if s.options.Workers > 1 && s.options.Parallelism == RootParallel {
+		trees = s.options.Workers
+	}
+
+	s.roots = make([]*monteCarloTreeSearchGameNode, trees)
+	for i := range s.roots {
+		root := rootMCTSNode(state)
+		root.table = newTranspositionTable(state, s.options)
+		s.roots[i] = &root
+	}
+
+	workers := s.options.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	s.rngs = make([]*rand.Rand, workers)
+	for w := range s.rngs {
+		s.rngs[w] = rand.New(rand.NewSource(s.options.Seed + int64(w)))
+	}
+}
+
+// State - game state at the root of the tree
+func (s *Searcher) State() GameState {
+	return s.roots[0].value
+}
+
+// Advance - move the root to the state reached by playing action, keeping the
+// subtree below it and discarding the rest. Actions are matched with ==, so
+// they must be comparable.
+func (s *Searcher) Advance(action Action) {
+	for i, root := range s.roots {
+		s.roots[i] = root.advance(action)
+	}
+}
+
+// Search - search from the root until ctx is done or the bounds of the
+// searcher's options are reached, returning the best action found
+func (s *Searcher) Search(ctx context.Context) Action {
+	return s.Analyze(ctx).Action
+}
+
+// Analyze - search like Search, reporting the statistics behind the action
+// chosen
+func (s *Searcher) Analyze(ctx context.Context) SearchResult {
+	start := time.Now()
+
+	if s.options.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.options.Duration)
+		defer cancel()
+	}
+
+	if ctx.Done() == nil && s.options.MaxSimulations <= 0 {
+		panic("gomcts: search has neither a deadline nor a simulation bound")
+	}
+
+	root, simulations := s.run(ctx, s.options)
+	if s.options.FinalMove == MaxRobustChild {
+		root, simulations = s.searchUntilMaxRobust(root, simulations)
+	}
+	best := root.finalChild(s.options)
+
+	return SearchResult{
+		Action:             best.causingAction,
+		Proven:             best.proven,
+		Result:             best.result,
+		Actions:            root.statistics(s.options.selection()),
+		PrincipalVariation: s.principalVariation(root, best),
+		Simulations:        simulations,
+		Elapsed:            time.Since(start),
+	}
+}
+
+// principalVariation - line starting with child of root and following the
+// most visited children below it. When root merges the trees of a root
+// parallel search the line continues in the tree that visited child most.
+func (s *Searcher) principalVariation(root, child *monteCarloTreeSearchGameNode) []Action {
+	node := child
+	if root != s.roots[0] {
+		index := 0
+		for root.children[index] != child {
+			index++
+		}
+		node = nil
+		for _, tree := range s.roots {
+			if index < len(tree.children) && (node == nil || tree.children[index].n > node.n) {
+				node = tree.children[index]
+			}
+		}
+	}
+
+	variation := make([]Action, 0)
+	for ; node != nil; node = node.mostVisitedChild() {
+		variation = append(variation, node.causingAction)
+	}
+	return variation
+}
+
+// run - search with the parallelism requested by options, returning a root
+// whose children hold the statistics of the search and the number of
+// simulations run
+func (s *Searcher) run(ctx context.Context, options SearchOptions) (*monteCarloTreeSearchGameNode, int) {
+	if options.Workers <= 1 {
+		return s.roots[0], s.roots[0].search(ctx, s.rolloutPolicy, options, s.rngs[0])
+	}
+
+	switch options.Parallelism {
+	case RootParallel:
+		return rootParallelSearch(ctx, s.roots, s.rolloutPolicy, options, s.rngs)
+	case TreeParallel:
+		return s.roots[0], s.roots[0].sharedSearch(ctx, s.rolloutPolicy, options, s.rngs)
+	}
+
+	panic("gomcts: unknown parallelism")
+}
+
+// advance - child of node reached by action, detached from node, or a fresh
+// root when that child has not been expanded yet
+func (node *monteCarloTreeSearchGameNode) advance(action Action) *monteCarloTreeSearchGameNode {
+	for _, child := range node.children {
+		if child.causingAction == action {
+			child.parent = nil
+			child.table = node.table
+			return child
+		}
+	}
+
+	root := rootMCTSNode(action.ApplyTo(node.value))
+	root.table = node.table
+	return &root
+}
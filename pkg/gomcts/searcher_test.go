@@ -0,0 +1,63 @@
+package gomcts
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSearcherAdvanceKeepsStatistics(t *testing.T) {
+	searcher := NewSearcher(nimGameState{stones: 40, nextToMove: 1}, nimFirstActionPolicy, SearchOptions{MaxSimulations: 500})
+	action := searcher.Search(context.Background())
+
+	var child *monteCarloTreeSearchGameNode
+	for _, c := range searcher.roots[0].children {
+		if c.causingAction == action {
+			child = c
+		}
+	}
+	visits := child.n
+
+	searcher.Advance(action)
+	root := searcher.roots[0]
+
+	if root != child {
+		t.Errorf("root should become the child reached by the action played")
+	}
+
+	if root.parent != nil {
+		t.Errorf("new root should be detached from its former parent")
+	}
+
+	searcher.Search(context.Background())
+	if root.n != visits+500 {
+		t.Errorf("root should be visited %v times but was visited %v times", visits+500, root.n)
+	}
+}
+
+func TestSearcherAdvanceToUnexpandedChild(t *testing.T) {
+	searcher := NewSearcher(nimGameState{stones: 12, nextToMove: 1}, nimFirstActionPolicy, SearchOptions{MaxSimulations: 1})
+	searcher.Search(context.Background())
+	searcher.Advance(nimAction{take: 3})
+
+	state := searcher.State().(nimGameState)
+	if state.stones != 9 || state.nextToMove != 2 {
+		t.Errorf("root should hold 9 stones with player 2 to move but holds %v with player %v to move", state.stones, state.nextToMove)
+	}
+
+	if searcher.roots[0].n != 0 {
+		t.Errorf("fresh root should not have been visited but was visited %v times", searcher.roots[0].n)
+	}
+}
+
+func TestRootParallelSearcherAdvancesEveryTree(t *testing.T) {
+	options := SearchOptions{MaxSimulations: 400, Workers: 4, Parallelism: RootParallel}
+	searcher := NewSearcher(nimGameState{stones: 12, nextToMove: 1}, nimFirstActionPolicy, options)
+	searcher.Search(context.Background())
+	searcher.Advance(nimAction{take: 1})
+
+	for i, root := range searcher.roots {
+		if root.value.(nimGameState).stones != 11 {
+			t.Errorf("tree %v should hold 11 stones but holds %v", i, root.value.(nimGameState).stones)
+		}
+	}
+}
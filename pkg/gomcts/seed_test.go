@@ -0,0 +1,35 @@
+package gomcts_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/unathi-skosana/gothello/pkg/gomcts"
+	"github.com/unathi-skosana/gothello/pkg/othello"
+)
+
+func TestSeededSearchIsReproducible(t *testing.T) {
+	for _, workers := range []int{1, 3} {
+		options := gomcts.SearchOptions{MaxSimulations: 300, Workers: workers, Seed: 42}
+		first := gomcts.MonteCarloTreeSearchAnalysis(context.Background(), othello.New(othello.BLUE), othello.OthelloRandomRolloutPolicy, options)
+		second := gomcts.MonteCarloTreeSearchAnalysis(context.Background(), othello.New(othello.BLUE), othello.OthelloRandomRolloutPolicy, options)
+
+		if !reflect.DeepEqual(first.Actions, second.Actions) {
+			t.Errorf("searches with %v workers and the same seed should gather the same statistics", workers)
+		}
+
+		if !reflect.DeepEqual(first.PrincipalVariation, second.PrincipalVariation) {
+			t.Errorf("searches with %v workers and the same seed should find the same line", workers)
+		}
+	}
+}
+
+func TestSearchesWithDifferentSeedsDiffer(t *testing.T) {
+	first := gomcts.MonteCarloTreeSearchAnalysis(context.Background(), othello.New(othello.BLUE), othello.OthelloRandomRolloutPolicy, gomcts.SearchOptions{MaxSimulations: 300, Seed: 1})
+	second := gomcts.MonteCarloTreeSearchAnalysis(context.Background(), othello.New(othello.BLUE), othello.OthelloRandomRolloutPolicy, gomcts.SearchOptions{MaxSimulations: 300, Seed: 2})
+
+	if reflect.DeepEqual(first.Actions, second.Actions) {
+		t.Errorf("searches with different seeds should gather different statistics")
+	}
+}
@@ -0,0 +1,131 @@
+package gomcts
+
+import (
+	"math"
+)
+
+// ChildStatistics - statistics of a child weighed by a SelectionPolicy.
+// Payoffs are those of the player moving into the child: 1 for a win, -1 for
+// a loss and 0 for a draw.
+type ChildStatistics struct {
+	// Visits - number of simulations through the child
+	Visits float64
+	// ParentVisits - number of simulations through the child's parent
+	ParentVisits float64
+	// Value - sum of payoffs
+	Value float64
+	// SquaredValue - sum of squared payoffs
+	SquaredValue float64
+	// Prior - prior probability of the action leading to the child, zero
+	// unless the policy is a PriorPolicy
+	Prior float64
+	// AMAFVisits - number of simulations in which the action leading to the
+	// child was played later on by the same player, zero unless the policy
+	// is RAVE
+	AMAFVisits float64
+	// AMAFValue - sum of payoffs of those simulations
+	AMAFValue float64
+}
+
+// SelectionPolicy - scores children while descending the tree, the child
+// with the highest score is descended into
+type SelectionPolicy interface {
+	Score(child ChildStatistics) float64
+}
+
+// PriorPolicy - SelectionPolicy weighing children by prior probabilities of
+// the actions leading to them
+type PriorPolicy interface {
+	SelectionPolicy
+	// Priors - prior probability of each of actions, the legal actions of state
+	Priors(state GameState, actions []Action) []float64
+}
+
+// explorationConstant - weight of the exploration term of UCB1 when no
+// selection policy is given
+const explorationConstant = 1.4
+
+// UCB1 - Upper Confidence Bound selection, mean payoff plus an exploration
+// term weighted by C
+type UCB1 struct {
+	C float64
+}
+
+// Score - UCB1 implementation of Score method of SelectionPolicy interface
+func (p UCB1) Score(child ChildStatistics) float64 {
+	return child.mean() + p.C*math.Sqrt(2*math.Log(child.ParentVisits)/child.Visits)
+}
+
+// UCB1Tuned - UCB1 whose exploration term shrinks with the observed variance
+// of the payoffs, weighted by C (1 in the original formulation)
+type UCB1Tuned struct {
+	C float64
+}
+
+// Score - UCB1Tuned implementation of Score method of SelectionPolicy interface
+func (p UCB1Tuned) Score(child ChildStatistics) float64 {
+	// the bound assumes payoffs within [0, 1], scale -1..1 payoffs x to (x+1)/2
+	mean := (child.mean() + 1) / 2
+	squaredMean := (child.SquaredValue/child.Visits + 2*child.mean() + 1) / 4
+	logParentVisits := math.Log(child.ParentVisits)
+	variance := squaredMean - mean*mean + math.Sqrt(2*logParentVisits/child.Visits)
+
+	return mean + p.C*math.Sqrt(logParentVisits/child.Visits*math.Min(0.25, variance))
+}
+
+// PUCT - Predictor + UCT selection as used by AlphaZero, mean payoff plus an
+// exploration term proportional to the prior probability of the action given
+// by Prior and weighted by C
+type PUCT struct {
+	C     float64
+	Prior func(state GameState, actions []Action) []float64
+}
+
+// Score - PUCT implementation of Score method of SelectionPolicy interface
+func (p PUCT) Score(child ChildStatistics) float64 {
+	mean := 0.0
+	if child.Visits > 0 {
+		mean = child.mean()
+	}
+	return mean + p.C*child.Prior*math.Sqrt(child.ParentVisits)/(1+child.Visits)
+}
+
+// Priors - PUCT implementation of Priors method of PriorPolicy interface,
+// uniform priors when Prior is nil
+func (p PUCT) Priors(state GameState, actions []Action) []float64 {
+	if p.Prior != nil {
+		return p.Prior(state, actions)
+	}
+
+	priors := make([]float64, len(actions))
+	for i := range priors {
+		priors[i] = 1 / float64(len(actions))
+	}
+	return priors
+}
+
+// mean - mean payoff of the child
+func (child ChildStatistics) mean() float64 {
+	return child.Value / child.Visits
+}
+
+// selection - selection policy of options, UCB1 when none is given
+func (options SearchOptions) selection() SelectionPolicy {
+	if options.Selection == nil {
+		return UCB1{C: explorationConstant}
+	}
+	return options.Selection
+}
+
+// score - score of node as a child of its parent under policy
+func (node *monteCarloTreeSearchGameNode) score(policy SelectionPolicy) float64 {
+	return policy.Score(ChildStatistics{
+		Visits:       node.n,
+		ParentVisits: node.parent.n,
+		Value:        node.q,
+		SquaredValue: node.q2,
+		Prior:        node.prior,
+		AMAFVisits:   node.amafN,
+		AMAFValue:    node.amafQ,
+	})
+}
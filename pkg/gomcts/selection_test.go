@@ -0,0 +1,57 @@
+package gomcts
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+func TestUCB1Score(t *testing.T) {
+	child := ChildStatistics{Visits: 10, ParentVisits: 100, Value: 4}
+	expected := 0.4 + 1.4*math.Sqrt(2*math.Log(100)/10)
+
+	if score := (UCB1{C: 1.4}).Score(child); math.Abs(score-expected) > 1e-12 {
+		t.Errorf("UCB1 score should be %v but is %v", expected, score)
+	}
+}
+
+func TestUCB1TunedPrefersLowVariance(t *testing.T) {
+	steady := ChildStatistics{Visits: 5000, ParentVisits: 10000, Value: 0, SquaredValue: 0}
+	erratic := ChildStatistics{Visits: 5000, ParentVisits: 10000, Value: 0, SquaredValue: 5000}
+	policy := UCB1Tuned{C: 1}
+
+	if policy.Score(steady) >= policy.Score(erratic) {
+		t.Errorf("a child of all draws should be explored less than one of wins and losses with the same mean")
+	}
+}
+
+func TestPUCTFollowsPriors(t *testing.T) {
+	// all the prior mass on taking 2 stones
+	policy := PUCT{C: 2, Prior: func(state GameState, actions []Action) []float64 {
+		priors := make([]float64, len(actions))
+		for i, action := range actions {
+			if action.(nimAction).take == 2 {
+				priors[i] = 1
+			}
+		}
+		return priors
+	}}
+
+	result := MonteCarloTreeSearchAnalysis(context.Background(), nimGameState{stones: 40, nextToMove: 1}, nimRandomPolicy, SearchOptions{MaxSimulations: 500, Selection: policy})
+
+	if result.Actions[1].Visits <= result.Actions[0].Visits || result.Actions[1].Visits <= result.Actions[2].Visits {
+		t.Errorf("the action with all the prior mass should be visited most but visits are %v, %v and %v", result.Actions[0].Visits, result.Actions[1].Visits, result.Actions[2].Visits)
+	}
+}
+
+func TestSelectionPoliciesFindWinningMove(t *testing.T) {
+	policies := []SelectionPolicy{UCB1{C: 0.7}, UCB1Tuned{C: 1}, PUCT{C: 1.5}}
+
+	for _, policy := range policies {
+		action := MonteCarloTreeSearchWithOptions(context.Background(), nimGameState{stones: 9, nextToMove: 1}, nimRandomPolicy, SearchOptions{MaxSimulations: 3000, Selection: policy})
+
+		if action.(nimAction).take != 1 {
+			t.Errorf("%T should take 1 stone but takes %v", policy, action.(nimAction).take)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package gomcts
+
+// MCTS-Solver: a node is proven once its outcome under perfect play is known.
+// Terminal nodes are proven by their result, a node whose player to move has
+// a child proven won is proven by that child, and a node whose children are
+// all proven is proven by the best of them. Proofs are propagated upwards
+// during backpropagation, proven nodes are never descended below and their
+// result replaces the rollout.
+
+// prove - mark node as proven once the results of its children decide it,
+// returning whether it is proven
+func (node *monteCarloTreeSearchGameNode) prove() bool {
+	if node.proven {
+		return true
+	}
+
+	player := node.value.NextToMove()
+	decided := node.isFullyExpanded() && len(node.children) > 0
+	best := node.bestProvenChild()
+	for _, child := range node.children {
+		if !child.proven {
+			decided = false
+		}
+	}
+
+	if best != nil && (decided || reward(best.result, player) == 1) {
+		node.proven = true
+		node.result = best.result
+	}
+	return node.proven
+}
+
+// bestProvenChild - proven child with the best result for the player to move,
+// nil when no child is proven
+func (node *monteCarloTreeSearchGameNode) bestProvenChild() *monteCarloTreeSearchGameNode {
+	player := node.value.NextToMove()
+
+	var best *monteCarloTreeSearchGameNode
+	for _, child := range node.children {
+		if child.proven && (best == nil || reward(child.result, player) > reward(best.result, player)) {
+			best = child
+		}
+	}
+	return best
+}
+
+// provenWin - whether node is proven won for the player that moved into it
+func (node *monteCarloTreeSearchGameNode) provenWin() bool {
+	return node.proven && reward(node.result, node.parent.value.NextToMove()) == 1
+}
+
+// provenLoss - whether node is proven lost for the player that moved into it
+func (node *monteCarloTreeSearchGameNode) provenLoss() bool {
+	return node.proven && reward(node.result, node.parent.value.NextToMove()) == -1
+}
+
+// provenLost - whether node is proven lost for its player to move
+func (node *monteCarloTreeSearchGameNode) provenLost() bool {
+	return node.proven && reward(node.result, node.value.NextToMove()) == -1
+}
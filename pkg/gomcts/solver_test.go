@@ -0,0 +1,50 @@
+package gomcts
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+)
+
+func TestSolverProvesWinningMove(t *testing.T) {
+	state := nimGameState{stones: 5, nextToMove: 1}
+	result := MonteCarloTreeSearchAnalysis(context.Background(), state, nimRandomPolicy, SearchOptions{MaxSimulations: 100000})
+
+	if !result.Proven || result.Result != GameResult(1) {
+		t.Errorf("taking 1 of 5 stones should be proven won for player 1 but proven is %v with result %v", result.Proven, result.Result)
+	}
+
+	if result.Action.(nimAction).take != 1 {
+		t.Errorf("player 1 should take 1 stone but takes %v", result.Action.(nimAction).take)
+	}
+
+	if result.Simulations >= 100000 {
+		t.Errorf("search should stop early once the root is proven but ran %v simulations", result.Simulations)
+	}
+}
+
+func TestSolverProvesLostPosition(t *testing.T) {
+	state := nimGameState{stones: 8, nextToMove: 2}
+	root := rootMCTSNode(state)
+	root.search(context.Background(), nimRandomPolicy, SearchOptions{MaxSimulations: 100000}, rand.New(rand.NewSource(1)))
+
+	if !root.proven || root.result != GameResult(1) {
+		t.Errorf("8 stones should be proven lost for player 2 to move but proven is %v with result %v", root.proven, root.result)
+	}
+
+	for _, child := range root.children {
+		if !child.provenLoss() {
+			t.Errorf("taking %v stones should be proven lost", child.causingAction.(nimAction).take)
+		}
+	}
+}
+
+func TestTerminalNodeIsProven(t *testing.T) {
+	root := rootMCTSNode(nimGameState{stones: 1, nextToMove: 2})
+	root.search(context.Background(), nimRandomPolicy, SearchOptions{MaxSimulations: 10}, rand.New(rand.NewSource(1)))
+
+	child := root.children[0]
+	if !child.proven || child.result != GameResult(2) || !child.provenWin() {
+		t.Errorf("taking the last stone should be a proven win for player 2")
+	}
+}
@@ -0,0 +1,83 @@
+package gomcts
+
+// With a transposition table the search still grows a tree, but nodes holding
+// equal states share their statistics, so a position reached by different
+// move orders is only evaluated once. Statistics are kept from the point of
+// view of the player moving into a node, so states are only shared between
+// nodes entered by the same player.
+
+// ReplacementScheme - rule deciding which state a full transposition table
+// forgets to make room for a new one
+type ReplacementScheme int
+
+const (
+	// ReplaceOldest - the state stored first in the bucket is forgotten
+	ReplaceOldest ReplacementScheme = iota
+	// ReplaceLeastVisited - the state of the bucket with the fewest visits is
+	// forgotten, keeping the statistics that took longest to gather
+	ReplaceLeastVisited
+)
+
+// transpositionBucketSize - number of states hashing to the same bucket
+const transpositionBucketSize = 2
+
+type transpositionEntry struct {
+	key        uint64
+	statistics *nodeStatistics
+}
+
+type transpositionTable struct {
+	buckets     [][transpositionBucketSize]transpositionEntry
+	replacement ReplacementScheme
+}
+
+// newTranspositionTable - table for searches of state bounded by options, nil
+// when options ask for none or state is not Hashable
+func newTranspositionTable(state GameState, options SearchOptions) *transpositionTable {
+	if _, ok := state.(Hashable); !ok || options.TranspositionTableSize <= 0 {
+		return nil
+	}
+
+	buckets := (options.TranspositionTableSize + transpositionBucketSize - 1) / transpositionBucketSize
+	return &transpositionTable{
+		buckets:     make([][transpositionBucketSize]transpositionEntry, buckets),
+		replacement: options.Replacement,
+	}
+}
+
+// statistics - statistics shared by the transpositions of node, which is
+// stored with its own statistics when the table holds none
+func (table *transpositionTable) statistics(node *monteCarloTreeSearchGameNode) *nodeStatistics {
+	// mix the player moving into the node into the hash
+	key := node.value.(Hashable).Hash() ^ uint64(node.parent.value.NextToMove())*0x9e3779b97f4a7c15
+	bucket := &table.buckets[key%uint64(len(table.buckets))]
+
+	for _, entry := range bucket {
+		if entry.statistics != nil && entry.key == key {
+			return entry.statistics
+		}
+	}
+
+	victim := -1
+	for i, entry := range bucket {
+		if entry.statistics == nil {
+			victim = i
+			break
+		}
+	}
+	if victim == -1 {
+		victim = len(bucket) - 1
+		for i, entry := range bucket {
+			if table.replacement == ReplaceLeastVisited && entry.statistics.n < bucket[victim].statistics.n {
+				victim = i
+			}
+		}
+	}
+	if table.replacement == ReplaceOldest && bucket[victim].statistics != nil {
+		// forget the first entry, the others move up a slot
+		copy(bucket[:], bucket[1:])
+	}
+
+	bucket[victim] = transpositionEntry{key: key, statistics: node.nodeStatistics}
+	return node.nodeStatistics
+}
@@ -0,0 +1,74 @@
+package gomcts
+
+import (
+	"context"
+	"testing"
+)
+
+// nodesByState - nodes of the tree below node, grouped by their state
+func nodesByState(node *monteCarloTreeSearchGameNode, nodes map[nimGameState][]*monteCarloTreeSearchGameNode) {
+	for _, child := range node.children {
+		state := child.value.(nimGameState)
+		nodes[state] = append(nodes[state], child)
+		nodesByState(child, nodes)
+	}
+}
+
+func TestTranspositionsShareStatistics(t *testing.T) {
+	options := SearchOptions{MaxSimulations: 2000, TranspositionTableSize: 1024}
+	searcher := NewSearcher(nimGameState{stones: 40, nextToMove: 1}, nimRandomPolicy, options)
+	searcher.Search(context.Background())
+
+	nodes := make(map[nimGameState][]*monteCarloTreeSearchGameNode)
+	nodesByState(searcher.roots[0], nodes)
+
+	// 40 - 1 - 2 and 40 - 2 - 1 both leave 37 stones with player 1 to move
+	transpositions := nodes[nimGameState{stones: 37, nextToMove: 1}]
+	if len(transpositions) < 2 {
+		t.Fatalf("37 stones should be reached by at least 2 move orders but is reached by %v", len(transpositions))
+	}
+	for _, node := range transpositions[1:] {
+		if node.nodeStatistics != transpositions[0].nodeStatistics {
+			t.Errorf("transpositions of 37 stones should share their statistics")
+		}
+	}
+}
+
+func TestWithoutTranspositionTableStatisticsAreNotShared(t *testing.T) {
+	searcher := NewSearcher(nimGameState{stones: 40, nextToMove: 1}, nimRandomPolicy, SearchOptions{MaxSimulations: 2000})
+	searcher.Search(context.Background())
+
+	nodes := make(map[nimGameState][]*monteCarloTreeSearchGameNode)
+	nodesByState(searcher.roots[0], nodes)
+
+	transpositions := nodes[nimGameState{stones: 37, nextToMove: 1}]
+	if len(transpositions) < 2 || transpositions[0].nodeStatistics == transpositions[1].nodeStatistics {
+		t.Errorf("nodes of a plain tree should have statistics of their own")
+	}
+}
+
+func TestTranspositionTableReplacement(t *testing.T) {
+	parent := rootMCTSNode(nimGameState{stones: 40, nextToMove: 1})
+	node := func(stones int, visits float64) *monteCarloTreeSearchGameNode {
+		node := newMCTSNode(&parent, nimGameState{stones: stones, nextToMove: 2}, nil)
+		node.n = visits
+		return &node
+	}
+
+	for _, replacement := range []ReplacementScheme{ReplaceOldest, ReplaceLeastVisited} {
+		// a single bucket
+		table := newTranspositionTable(parent.value, SearchOptions{TranspositionTableSize: 2, Replacement: replacement})
+		first, second, third := node(1, 5), node(2, 1), node(3, 0)
+		table.statistics(first)
+		table.statistics(second)
+		table.statistics(third)
+
+		kept := first
+		if replacement == ReplaceOldest {
+			kept = second
+		}
+		if table.statistics(node(kept.value.(nimGameState).stones, 0)) != kept.nodeStatistics {
+			t.Errorf("scheme %v should keep the statistics of %v stones", replacement, kept.value.(nimGameState).stones)
+		}
+	}
+}
@@ -0,0 +1,304 @@
+package othello
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/unathi-skosana/gothello/pkg/gomcts"
+)
+
+// ALPHA_BETA_TRANSPOSITIONS - default number of entries of the transposition
+// table of AlphaBeta
+const ALPHA_BETA_TRANSPOSITIONS = 1 << 16
+
+// score of a won game before the final disc difference is added, above any
+// evaluation
+const winScore = 1e6
+
+// nodes searched between checks of the deadline
+const deadlineInterval = 1024
+
+// Evaluator - heuristic score of a game state for the player to move, the
+// higher the better
+type Evaluator func(s OthelloGameState) float64
+
+// AlphaBetaOptions - bounds and evaluation of an AlphaBeta search, it stops
+// after the iteration of Depth or once Duration passed, whichever comes first
+type AlphaBetaOptions struct {
+	// Depth - deepest iteration in plies, 0 for no bound
+	Depth int
+	// Duration - time allowed for the search, 0 for no bound
+	Duration time.Duration
+	// TranspositionTableSize - entries of the transposition table, rounded
+	// down to a power of two, ALPHA_BETA_TRANSPOSITIONS when 0
+	TranspositionTableSize int
+	// Evaluator - score of the positions at the end of an iteration,
+	// HARD_WEIGHTS heuristics when nil
+	Evaluator Evaluator
+}
+
+// AlphaBetaResult - outcome of an AlphaBeta search
+type AlphaBetaResult struct {
+	Action OthelloBoardGameAction
+	// Score - score of Action for the player to move, beyond +-1e6 for a
+	// game won or lost by the difference beyond it, empty squares counting
+	// for the winner
+	Score float64
+	// Depth - depth of the last iteration completed
+	Depth int
+	// Exact - whether every line searched reached the end of the game, so
+	// Score is the final outcome
+	Exact   bool
+	Nodes   uint64
+	Elapsed time.Duration
+}
+
+// AlphaBeta - iterative deepening alpha-beta search of state, trying the
+// best move of the transposition table first and the others by their
+// evaluation. The first iteration always completes so an action is returned
+// even when ctx is already done.
+func AlphaBeta(ctx context.Context, state gomcts.GameState, options AlphaBetaOptions) AlphaBetaResult {
+	start := time.Now()
+	s := mailbox(state)
+
+	if s.IsGameEnded() {
+		panic("*hands slapped*,  game is already over")
+	}
+
+	if options.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Duration)
+		defer cancel()
+	}
+
+	if ctx.Done() == nil && options.Depth <= 0 {
+		panic("*hands slapped*,  search has neither a deadline nor a depth")
+	}
+
+	search := newAlphaBetaSearch(options)
+	result := AlphaBetaResult{}
+
+	// every line ends within two plies per empty square, counting passes
+	deepest := 2*s.Empties() + 1
+	if options.Depth > 0 && options.Depth < deepest {
+		deepest = options.Depth
+	}
+
+	for depth := 1; depth <= deepest; depth++ {
+		if depth > 1 && ctx.Err() != nil {
+			break
+		}
+
+		search.cut = false
+		action, score := search.root(s, depth)
+		if search.aborted {
+			break
+		}
+
+		result.Action, result.Score, result.Depth = action, score, depth
+		result.Exact = !search.cut
+		if result.Exact {
+			break
+		}
+
+		// deadline checks only start once an action is known
+		search.ctx = ctx
+	}
+
+	result.Nodes = search.nodes
+	result.Elapsed = time.Since(start)
+	return result
+}
+
+// transposition table flags
+const (
+	exactScore = iota
+	lowerBound
+	upperBound
+)
+
+// alphaBetaEntry - transposition table entry
+type alphaBetaEntry struct {
+	hash  uint64
+	depth int
+	flag  int
+	score float64
+	move  int
+	// exact - whether no line below the position was cut by depth
+	exact bool
+}
+
+// alphaBetaSearch - state of an AlphaBeta search
+type alphaBetaSearch struct {
+	ctx       context.Context
+	evaluator Evaluator
+	table     []alphaBetaEntry
+	nodes     uint64
+	// cut - whether a line of the current iteration was cut by its depth
+	cut     bool
+	aborted bool
+}
+
+// newAlphaBetaSearch - initializes a new alphaBetaSearch, which doesn't stop
+// until its ctx is set after the first iteration
+func newAlphaBetaSearch(options AlphaBetaOptions) *alphaBetaSearch {
+	size := options.TranspositionTableSize
+	if size <= 0 {
+		size = ALPHA_BETA_TRANSPOSITIONS
+	}
+	for size&(size-1) != 0 {
+		size &= size - 1
+	}
+
+	evaluator := options.Evaluator
+	if evaluator == nil {
+		evaluator = HeuristicEvaluator(HARD_WEIGHTS)
+	}
+
+	return &alphaBetaSearch{ctx: context.Background(), evaluator: evaluator, table: make([]alphaBetaEntry, size)}
+}
+
+// root - best action of s and its score searching depth plies
+func (a *alphaBetaSearch) root(s OthelloGameState, depth int) (OthelloBoardGameAction, float64) {
+	alpha := math.Inf(-1)
+	var best OthelloBoardGameAction
+
+	entry, _ := a.probe(s.Hash())
+	for _, action := range a.order(s, entry.move) {
+		score := -a.negamax(action.ApplyTo(s).(OthelloGameState), depth-1, math.Inf(-1), -alpha)
+		if a.aborted {
+			return best, alpha
+		}
+		if score > alpha {
+			alpha, best = score, action
+		}
+	}
+
+	a.store(alphaBetaEntry{hash: s.Hash(), depth: depth, flag: exactScore, score: alpha, move: best.move, exact: !a.cut})
+	return best, alpha
+}
+
+// negamax - score of s for the player to move searching depth plies within
+// the window alpha, beta
+func (a *alphaBetaSearch) negamax(s OthelloGameState, depth int, alpha, beta float64) float64 {
+	a.nodes++
+	if a.nodes%deadlineInterval == 0 && a.ctx.Err() != nil {
+		a.aborted = true
+	}
+	if a.aborted {
+		return 0
+	}
+
+	if s.IsGameEnded() {
+		player, opp := s.Bitboard().sides()
+		score := finalScore(player, opp)
+		if score > 0 {
+			return winScore + float64(score)
+		} else if score < 0 {
+			return -winScore + float64(score)
+		}
+		return 0
+	}
+
+	if depth == 0 {
+		a.cut = true
+		return a.evaluator(s)
+	}
+
+	hash := s.Hash()
+	entry, found := a.probe(hash)
+	if found && entry.depth >= depth {
+		cut := a.cut || !entry.exact
+		switch entry.flag {
+		case exactScore:
+			a.cut = cut
+			return entry.score
+		case lowerBound:
+			alpha = math.Max(alpha, entry.score)
+		case upperBound:
+			beta = math.Min(beta, entry.score)
+		}
+		if alpha >= beta {
+			a.cut = cut
+			return entry.score
+		}
+	}
+
+	// whether lines below this position are cut, apart from the others
+	outerCut := a.cut
+	a.cut = false
+
+	original := alpha
+	best := math.Inf(-1)
+	bestMove := 0
+	for _, action := range a.order(s, entry.move) {
+		score := -a.negamax(action.ApplyTo(s).(OthelloGameState), depth-1, -beta, -alpha)
+		if a.aborted {
+			return 0
+		}
+		if score > best {
+			best, bestMove = score, action.move
+		}
+		if best > alpha {
+			alpha = best
+		}
+		if alpha >= beta {
+			break
+		}
+	}
+
+	flag := exactScore
+	if best <= original {
+		flag = upperBound
+	} else if best >= beta {
+		flag = lowerBound
+	}
+	a.store(alphaBetaEntry{hash: hash, depth: depth, flag: flag, score: best, move: bestMove, exact: !a.cut})
+	a.cut = a.cut || outerCut
+
+	return best
+}
+
+// order - legal actions of s, the move of the transposition table first and
+// the others by how little they leave the opponent according to the
+// evaluator
+func (a *alphaBetaSearch) order(s OthelloGameState, first int) []OthelloBoardGameAction {
+	legal := s.GetLegalActions()
+	actions := make([]OthelloBoardGameAction, len(legal))
+	scores := make(map[int]float64, len(legal))
+	for i, action := range legal {
+		actions[i] = action.(OthelloBoardGameAction)
+		if actions[i].move == first {
+			scores[actions[i].move] = math.Inf(-1)
+		} else {
+			scores[actions[i].move] = a.evaluator(actions[i].ApplyTo(s).(OthelloGameState))
+		}
+	}
+
+	sort.SliceStable(actions, func(i, j int) bool {
+		return scores[actions[i].move] < scores[actions[j].move]
+	})
+	return actions
+}
+
+// probe - entry of the position of hash in the transposition table, false
+// and an empty entry when there is none
+func (a *alphaBetaSearch) probe(hash uint64) (alphaBetaEntry, bool) {
+	entry := a.table[hash&uint64(len(a.table)-1)]
+	if entry.hash != hash {
+		return alphaBetaEntry{}, false
+	}
+	return entry, true
+}
+
+// store - keep entry in the transposition table, replacing the entry of its
+// slot unless that one is of another position searched deeper
+func (a *alphaBetaSearch) store(entry alphaBetaEntry) {
+	slot := &a.table[entry.hash&uint64(len(a.table)-1)]
+	if slot.hash != entry.hash && slot.depth > entry.depth {
+		return
+	}
+	*slot = entry
+}
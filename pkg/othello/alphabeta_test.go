@@ -0,0 +1,95 @@
+package othello
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/unathi-skosana/gothello/pkg/gomcts"
+)
+
+// score of state searching depth plies with plain negamax
+func negamaxScore(state OthelloGameState, depth int, evaluator Evaluator) float64 {
+	if state.IsGameEnded() {
+		player, opp := state.Bitboard().sides()
+		score := finalScore(player, opp)
+		if score > 0 {
+			return winScore + float64(score)
+		} else if score < 0 {
+			return -winScore + float64(score)
+		}
+		return 0
+	}
+
+	if depth == 0 {
+		return evaluator(state)
+	}
+
+	best := math.Inf(-1)
+	for _, action := range state.GetLegalActions() {
+		best = math.Max(best, -negamaxScore(action.ApplyTo(state).(OthelloGameState), depth-1, evaluator))
+	}
+	return best
+}
+
+func TestAlphaBetaMatchesNegamax(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	evaluator := HeuristicEvaluator(HARD_WEIGHTS)
+
+	for i := 0; i < 10; i++ {
+		state := randomPosition(rng, 20+rng.Intn(30)).Mailbox()
+		result := AlphaBeta(context.Background(), state, AlphaBetaOptions{Depth: 3, Evaluator: evaluator})
+
+		if want := negamaxScore(state, result.Depth, evaluator); math.Abs(result.Score-want) > 1e-9 {
+			t.Errorf("%v should score %v at depth %v but scores %v", state, want, result.Depth, result.Score)
+		}
+	}
+}
+
+func TestAlphaBetaExactInEndgame(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+
+	for i := 0; i < 10; i++ {
+		state := randomPosition(rng, 6)
+		result := AlphaBeta(context.Background(), state, AlphaBetaOptions{Duration: time.Minute})
+
+		if !result.Exact {
+			t.Fatalf("%v should be searched to the end", state)
+		}
+
+		want := SolveEndgame(state).Score
+		score := 0
+		if result.Score > 0 {
+			score = int(result.Score - winScore)
+		} else if result.Score < 0 {
+			score = int(result.Score + winScore)
+		}
+		if score != want {
+			t.Errorf("%v should end %v but ends %v", state, want, score)
+		}
+	}
+}
+
+func TestAlphaBetaReturnsActionAfterDeadline(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result := AlphaBeta(ctx, NewBitboard(BLUE), AlphaBetaOptions{})
+	if result.Depth != 1 || !IsLegalAction(New(BLUE), result.Action) {
+		t.Errorf("search should complete its first iteration but returns %v at depth %v", result.Action, result.Depth)
+	}
+}
+
+func TestUnboundedAlphaBetaPanics(t *testing.T) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Errorf("The code did not panic but should")
+		}
+	}()
+
+	var state gomcts.GameState = New(BLUE)
+	AlphaBeta(context.Background(), state, AlphaBetaOptions{})
+}
@@ -0,0 +1,231 @@
+package othello
+
+import (
+	"math/bits"
+
+	"github.com/unathi-skosana/gothello/pkg/gomcts"
+)
+
+// Bitboards hold one bit per square, bit 0 is a1, bit 7 is h1 and bit 63 is
+// h8, i.e. bit 8*(row-1) + (col-1) for mailbox square col + 10*row. Bits are
+// in the same order as the mailbox squares, so both representations list
+// legal actions in the same order.
+
+// bitboard shifts towards E, W, S, N, SE, NW, SW and NE, the mask of each
+// clears the squares that wrapped around an edge of the board
+var bitboardShifts = [DIR]int{1, -1, 8, -8, 9, -9, 7, -7}
+var bitboardMasks = [DIR]uint64{
+	0xfefefefefefefefe, 0x7f7f7f7f7f7f7f7f,
+	0xffffffffffffffff, 0xffffffffffffffff,
+	0xfefefefefefefefe, 0x7f7f7f7f7f7f7f7f,
+	0x7f7f7f7f7f7f7f7f, 0xfefefefefefefefe,
+}
+
+// OthelloBitboardGameState - Othello game state stored as a pair of
+// bitboards, behaving exactly like OthelloGameState but faster to play on
+type OthelloBitboardGameState struct {
+	nextToMove int
+	blue       uint64
+	red        uint64
+}
+
+// NewBitboard - initializes a new OthelloBitboardGameState object
+func NewBitboard(nextToMove int) OthelloBitboardGameState {
+	return New(nextToMove).Bitboard()
+}
+
+// Discs - Get the bitboards of BLUE's and RED's discs
+func (s OthelloBitboardGameState) Discs() (blue, red uint64) {
+	return s.blue, s.red
+}
+
+// Bitboard - Get the OthelloBitboardGameState equal to the current game state
+func (s OthelloGameState) Bitboard() OthelloBitboardGameState {
+	b := OthelloBitboardGameState{nextToMove: s.nextToMove}
+	for i := FIRST_BLOCK; i <= LAST_BLOCK; i++ {
+		if !bound(i) {
+			continue
+		}
+		switch s.board[i] {
+		case BLUE:
+			b.blue |= squareBit(i)
+		case RED:
+			b.red |= squareBit(i)
+		}
+	}
+	return b
+}
+
+// Mailbox - Get the OthelloGameState equal to the current game state
+func (s OthelloBitboardGameState) Mailbox() OthelloGameState {
+	board := make([]int, BOARD_SIZE)
+	for i := FIRST_BLOCK; i <= LAST_BLOCK; i++ {
+		if !bound(i) {
+			continue
+		}
+		if s.blue&squareBit(i) != 0 {
+			board[i] = BLUE
+		} else if s.red&squareBit(i) != 0 {
+			board[i] = RED
+		}
+	}
+	return OthelloGameState{nextToMove: s.nextToMove, board: board, hash: zobristHash(board, s.nextToMove)}
+}
+
+// IsGameEnded - OthelloBitboardGameState implementation of IsGameEnded method of GameState interface
+func (s OthelloBitboardGameState) IsGameEnded() bool {
+	return bitboardMoves(s.blue, s.red) == 0 && bitboardMoves(s.red, s.blue) == 0
+}
+
+// EvaluateGame - OthelloBitboardGameState implementation of EvaluateGame method of GameState interface
+func (s OthelloBitboardGameState) EvaluateGame() (gomcts.GameResult, bool) {
+	if !s.IsGameEnded() {
+		return gomcts.GameResult(EMPTY), false
+	}
+
+	blueSum := bits.OnesCount64(s.blue)
+	redSum := bits.OnesCount64(s.red)
+
+	if blueSum > redSum {
+		return gomcts.GameResult(BLUE), true
+	} else if blueSum < redSum {
+		return gomcts.GameResult(RED), true
+	}
+	// draw
+	return gomcts.GameResult(EMPTY), true
+}
+
+// GetLegalActions - OthelloBitboardGameState implementation of GetLegalAction method of GameState interface
+func (s OthelloBitboardGameState) GetLegalActions() []gomcts.Action {
+	player, opp := s.sides()
+	moves := bitboardMoves(player, opp)
+
+	// No moves, the player passes unless the game is over
+	if moves == 0 {
+		if bitboardMoves(opp, player) != 0 {
+			return []gomcts.Action{OthelloBoardGameAction{move: PASS, value: s.nextToMove}}
+		}
+		return []gomcts.Action{}
+	}
+
+	actions := make([]gomcts.Action, 0, bits.OnesCount64(moves))
+	for ; moves != 0; moves &= moves - 1 {
+		square := bits.TrailingZeros64(moves)
+		actions = append(actions, OthelloBoardGameAction{move: bitSquare(square), value: s.nextToMove})
+	}
+	return actions
+}
+
+// NextToMove - OthelloBitboardGameState implementation of NextToMove method of GameState interface
+func (s OthelloBitboardGameState) NextToMove() int {
+	return s.nextToMove
+}
+
+// Hash - OthelloBitboardGameState implementation of Hash method of Hashable
+// interface, the Zobrist hash of the equal OthelloGameState
+func (s OthelloBitboardGameState) Hash() uint64 {
+	return zobristBitboardHash(s.blue, s.red, s.nextToMove)
+}
+
+// GetScore - Get the current score
+func (s OthelloBitboardGameState) GetScore() (p1, p2 int) {
+	return bits.OnesCount64(s.blue), bits.OnesCount64(s.red)
+}
+
+// applyBitboard - bitboard counterpart of OthelloBoardGameAction.ApplyTo
+func (a OthelloBoardGameAction) applyBitboard(s OthelloBitboardGameState) OthelloBitboardGameState {
+	if s.nextToMove != a.value {
+		panic("*hands slapped*,  not your turn")
+	}
+
+	if a.move == PASS {
+		if player, opp := s.sides(); bitboardMoves(player, opp) != 0 {
+			panic("*hands slapped*,  can't pass with moves available")
+		}
+		s.nextToMove = opponent(a.value)
+		return s
+	}
+
+	if !bound(a.move) {
+		panic("*hands slapped*,  move out of bounds")
+	}
+
+	square := squareBit(a.move)
+	if (s.blue|s.red)&square != 0 {
+		panic("*hands slapped*,  square already occupied")
+	}
+
+	player, opp := s.sides()
+	flips := bitboardFlips(player, opp, square)
+	player |= square | flips
+	opp &^= flips
+
+	if a.value == BLUE {
+		s.blue, s.red = player, opp
+	} else {
+		s.red, s.blue = player, opp
+	}
+
+	s.nextToMove = opponent(a.value)
+
+	return s
+}
+
+// sides - bitboards of the player to move and of the opponent
+func (s OthelloBitboardGameState) sides() (player, opp uint64) {
+	if s.nextToMove == BLUE {
+		return s.blue, s.red
+	}
+	return s.red, s.blue
+}
+
+// shifts a bitboard one square in direction dir
+func shiftBitboard(b uint64, dir int) uint64 {
+	if shift := bitboardShifts[dir]; shift > 0 {
+		return (b << uint(shift)) & bitboardMasks[dir]
+	}
+	return (b >> uint(-bitboardShifts[dir])) & bitboardMasks[dir]
+}
+
+// gets the squares where player can move, i.e. empty squares from which a
+// run of opponent's pieces ends at one of player's pieces
+func bitboardMoves(player, opp uint64) uint64 {
+	empty := ^(player | opp)
+
+	var moves uint64
+	for dir := 0; dir < DIR; dir++ {
+		run := shiftBitboard(player, dir) & opp
+		for i := 0; i < BOARD_WIDTH-3; i++ {
+			run |= shiftBitboard(run, dir) & opp
+		}
+		moves |= shiftBitboard(run, dir) & empty
+	}
+	return moves
+}
+
+// gets the opponent's pieces flipped by player placing a piece on square
+func bitboardFlips(player, opp, square uint64) uint64 {
+	var flips uint64
+	for dir := 0; dir < DIR; dir++ {
+		var run uint64
+		c := shiftBitboard(square, dir)
+		for c&opp != 0 {
+			run |= c
+			c = shiftBitboard(c, dir)
+		}
+		if c&player != 0 {
+			flips |= run
+		}
+	}
+	return flips
+}
+
+// bit of a mailbox square
+func squareBit(move int) uint64 {
+	return 1 << uint(8*(move/10-1)+move%10-1)
+}
+
+// mailbox square of a bit index
+func bitSquare(square int) int {
+	return 10*(square/8+1) + square%8 + 1
+}
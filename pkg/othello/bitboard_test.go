@@ -0,0 +1,113 @@
+package othello
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/unathi-skosana/gothello/pkg/gomcts"
+)
+
+func TestBitboardRoundTrip(t *testing.T) {
+	state := New(BLUE)
+	if !reflect.DeepEqual(state.Bitboard().Mailbox(), state) {
+		t.Errorf("converting to a bitboard and back should give the same state")
+	}
+}
+
+func TestBitboardMatchesMailbox(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for game := 0; game < 200; game++ {
+		var mailbox gomcts.GameState = New(BLUE)
+		var bitboard gomcts.GameState = NewBitboard(BLUE)
+
+		for {
+			mailboxActions := mailbox.GetLegalActions()
+			bitboardActions := bitboard.GetLegalActions()
+			if !reflect.DeepEqual(mailboxActions, bitboardActions) {
+				t.Fatalf("game %v: legal actions differ, %v and %v", game, mailboxActions, bitboardActions)
+			}
+
+			if !reflect.DeepEqual(mailbox, bitboard.(OthelloBitboardGameState).Mailbox()) {
+				t.Fatalf("game %v: states differ", game)
+			}
+
+			mailboxResult, mailboxEnded := mailbox.EvaluateGame()
+			bitboardResult, bitboardEnded := bitboard.EvaluateGame()
+			if mailboxResult != bitboardResult || mailboxEnded != bitboardEnded {
+				t.Fatalf("game %v: evaluations differ, %v %v and %v %v", game, mailboxResult, mailboxEnded, bitboardResult, bitboardEnded)
+			}
+
+			if mailbox.IsGameEnded() != bitboard.IsGameEnded() || mailbox.NextToMove() != bitboard.NextToMove() {
+				t.Fatalf("game %v: game ended or player to move differ", game)
+			}
+
+			if mailboxEnded {
+				break
+			}
+
+			action := mailboxActions[rng.Intn(len(mailboxActions))]
+			mailbox = action.ApplyTo(mailbox)
+			bitboard = action.ApplyTo(bitboard)
+		}
+	}
+}
+
+func TestBitboardNotYourTurnPanic(t *testing.T) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Errorf("The code did not panic but should")
+		}
+	}()
+
+	state := NewBitboard(1)
+	action := OthelloBoardGameAction{move: 34, value: 2}
+	action.ApplyTo(state)
+}
+
+func TestBitboardAlreadyOccupiedSquareMovePanic(t *testing.T) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Errorf("The code did not panic but should")
+		}
+	}()
+
+	state := NewBitboard(1)
+	action := OthelloBoardGameAction{move: 44, value: 1}
+	action.ApplyTo(state)
+}
+
+func benchmarkRollouts(b *testing.B, state gomcts.GameState) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < b.N; i++ {
+		current := state
+		for !current.IsGameEnded() {
+			current = OthelloRandomRolloutPolicy(current, rng).ApplyTo(current)
+		}
+	}
+}
+
+func BenchmarkMailboxRollout(b *testing.B) {
+	benchmarkRollouts(b, New(BLUE))
+}
+
+func BenchmarkBitboardRollout(b *testing.B) {
+	benchmarkRollouts(b, NewBitboard(BLUE))
+}
+
+func BenchmarkMailboxLegalActions(b *testing.B) {
+	state := New(BLUE)
+	for i := 0; i < b.N; i++ {
+		state.GetLegalActions()
+	}
+}
+
+func BenchmarkBitboardLegalActions(b *testing.B) {
+	state := NewBitboard(BLUE)
+	for i := 0; i < b.N; i++ {
+		state.GetLegalActions()
+	}
+}
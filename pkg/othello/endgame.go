@@ -0,0 +1,190 @@
+package othello
+
+import (
+	"math/bits"
+
+	"github.com/unathi-skosana/gothello/pkg/gomcts"
+)
+
+// ENDGAME_EMPTIES - empty squares below which the AI solves the game exactly
+// by default
+const ENDGAME_EMPTIES = 14
+
+// empty squares above which moves are sorted fastest first, below it they're
+// only split by parity as sorting costs more than it saves
+const fastestFirstEmpties = 7
+
+// quadrants of the board, for parity
+var quadrants = [4]uint64{
+	0x000000000f0f0f0f, 0x00000000f0f0f0f0,
+	0x0f0f0f0f00000000, 0xf0f0f0f000000000,
+}
+
+// corners of the board
+const corners = 0x8100000000000081
+
+// Endgame - exact outcome of a position with perfect play
+type Endgame struct {
+	// Score - final disc difference for the player to move, empty squares
+	// counting for the winner
+	Score int
+	// Action - a move reaching Score, fastest first among the equals found
+	Action OthelloBoardGameAction
+	// Nodes - positions searched
+	Nodes uint64
+}
+
+// SolveEndgame - solve state exactly with alpha-beta search. The time taken
+// grows exponentially with the empty squares, so it's meant for the last
+// twenty or so moves. state must not be over.
+func SolveEndgame(state gomcts.GameState) Endgame {
+	s := bitboard(state)
+	if s.IsGameEnded() {
+		panic("*hands slapped*,  game is already over")
+	}
+
+	solver := endgameSolver{}
+	player, opp := s.sides()
+	moves := bitboardMoves(player, opp)
+
+	// no moves, the only action is to pass
+	if moves == 0 {
+		return Endgame{
+			Score:  -solver.negamax(opp, player, -PIECE_SLOTS, PIECE_SLOTS),
+			Action: OthelloBoardGameAction{move: PASS, value: s.nextToMove},
+			Nodes:  solver.nodes + 1,
+		}
+	}
+
+	best := Endgame{Score: -PIECE_SLOTS - 1}
+	alpha := -PIECE_SLOTS
+	for _, square := range solver.order(player, opp, moves) {
+		move := uint64(1) << uint(square)
+		flips := bitboardFlips(player, opp, move)
+		score := -solver.negamax(opp&^flips, player|move|flips, -PIECE_SLOTS, -alpha)
+		if score > best.Score {
+			best.Score = score
+			best.Action = OthelloBoardGameAction{move: bitSquare(square), value: s.nextToMove}
+		}
+		if score > alpha {
+			alpha = score
+		}
+	}
+	best.Nodes = solver.nodes + 1
+
+	return best
+}
+
+// Empties - Get the number of empty squares
+func (s OthelloGameState) Empties() int {
+	return PIECE_SLOTS - count(s.board, BLUE) - count(s.board, RED)
+}
+
+// Empties - Get the number of empty squares
+func (s OthelloBitboardGameState) Empties() int {
+	return PIECE_SLOTS - bits.OnesCount64(s.blue|s.red)
+}
+
+// endgameSolver - negamax search counting the positions it visits
+type endgameSolver struct {
+	nodes uint64
+}
+
+// negamax - score of the player to move within the window alpha, beta
+func (e *endgameSolver) negamax(player, opp uint64, alpha, beta int) int {
+	e.nodes++
+
+	moves := bitboardMoves(player, opp)
+	if moves == 0 {
+		if bitboardMoves(opp, player) == 0 {
+			return finalScore(player, opp)
+		}
+		return -e.negamax(opp, player, -beta, -alpha)
+	}
+
+	for _, square := range e.order(player, opp, moves) {
+		move := uint64(1) << uint(square)
+		flips := bitboardFlips(player, opp, move)
+		score := -e.negamax(opp&^flips, player|move|flips, -beta, -alpha)
+		if score >= beta {
+			return score
+		}
+		if score > alpha {
+			alpha = score
+		}
+	}
+
+	return alpha
+}
+
+// order - squares of moves in the order to search them: corners, then moves
+// leaving the opponent fewest replies (fastest first), then moves into
+// quadrants with an odd number of empty squares (parity)
+func (e *endgameSolver) order(player, opp, moves uint64) []int {
+	empty := ^(player | opp)
+
+	var odd uint64
+	for _, quadrant := range quadrants {
+		if bits.OnesCount64(empty&quadrant)%2 == 1 {
+			odd |= quadrant
+		}
+	}
+
+	squares := make([]int, 0, bits.OnesCount64(moves))
+	if bits.OnesCount64(empty) <= fastestFirstEmpties {
+		for m := moves & odd; m != 0; m &= m - 1 {
+			squares = append(squares, bits.TrailingZeros64(m))
+		}
+		for m := moves &^ odd; m != 0; m &= m - 1 {
+			squares = append(squares, bits.TrailingZeros64(m))
+		}
+		return squares
+	}
+
+	keys := make([]int, 0, cap(squares))
+	for m := moves; m != 0; m &= m - 1 {
+		square := bits.TrailingZeros64(m)
+		move := uint64(1) << uint(square)
+		flips := bitboardFlips(player, opp, move)
+
+		key := 4 * bits.OnesCount64(bitboardMoves(opp&^flips, player|move|flips))
+		if move&corners != 0 {
+			key -= 8
+		}
+		if move&odd != 0 {
+			key--
+		}
+
+		// insertion sort, there are few moves
+		i := len(squares)
+		squares = append(squares, square)
+		keys = append(keys, key)
+		for ; i > 0 && keys[i-1] > key; i-- {
+			squares[i], keys[i] = squares[i-1], keys[i-1]
+		}
+		squares[i], keys[i] = square, key
+	}
+	return squares
+}
+
+// final disc difference for player, empty squares counting for the winner
+func finalScore(player, opp uint64) int {
+	p := bits.OnesCount64(player)
+	o := bits.OnesCount64(opp)
+	empties := PIECE_SLOTS - p - o
+
+	if p > o {
+		return p - o + empties
+	} else if p < o {
+		return p - o - empties
+	}
+	return 0
+}
+
+// bitboard - OthelloBitboardGameState of either representation of a state
+func bitboard(state gomcts.GameState) OthelloBitboardGameState {
+	if m, ok := state.(OthelloGameState); ok {
+		return m.Bitboard()
+	}
+	return state.(OthelloBitboardGameState)
+}
@@ -0,0 +1,93 @@
+package othello
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/unathi-skosana/gothello/pkg/gomcts"
+)
+
+// plays random moves from the initial position until empties squares are
+// left, starting over when the game ends before
+func randomPosition(rng *rand.Rand, empties int) OthelloBitboardGameState {
+	for {
+		state := NewBitboard(BLUE)
+		for !state.IsGameEnded() && state.Empties() > empties {
+			actions := state.GetLegalActions()
+			state = actions[rng.Intn(len(actions))].ApplyTo(state).(OthelloBitboardGameState)
+		}
+		if !state.IsGameEnded() {
+			return state
+		}
+	}
+}
+
+// exact score by plain minimax through the GameState interface
+func minimaxScore(state gomcts.GameState) int {
+	if state.IsGameEnded() {
+		s := state.(OthelloBitboardGameState)
+		player, opp := s.sides()
+		return finalScore(player, opp)
+	}
+
+	best := -PIECE_SLOTS
+	for _, action := range state.GetLegalActions() {
+		if score := -minimaxScore(action.ApplyTo(state)); score > best {
+			best = score
+		}
+	}
+	return best
+}
+
+func TestSolveEndgameMatchesMinimax(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 30; i++ {
+		state := randomPosition(rng, 8)
+		want := minimaxScore(state)
+
+		endgame := SolveEndgame(state)
+		if endgame.Score != want {
+			t.Fatalf("%v should score %v but scores %v", state, want, endgame.Score)
+		}
+
+		if score := -minimaxScore(endgame.Action.ApplyTo(state)); score != want {
+			t.Errorf("%v in %v should score %v but scores %v", endgame.Action, state, want, score)
+		}
+	}
+}
+
+func TestSolveEndgamePass(t *testing.T) {
+	// blue can't move, red takes c1 and wins with every disc
+	state, _ := ParsePosition("OX-------------------------------------------------------------- X")
+
+	endgame := SolveEndgame(state)
+	if !endgame.Action.IsPass() || endgame.Score != -PIECE_SLOTS {
+		t.Errorf("blue should pass and lose by %v but plays %v scoring %v", PIECE_SLOTS, endgame.Action, endgame.Score)
+	}
+}
+
+func TestSolveEndedGamePanics(t *testing.T) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Errorf("The code did not panic but should")
+		}
+	}()
+
+	state, _ := ParsePosition("OOO------------------------------------------------------------- X")
+	SolveEndgame(state)
+}
+
+func BenchmarkSolveEndgame(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	positions := make([]OthelloBitboardGameState, 10)
+	for i := range positions {
+		positions[i] = randomPosition(rng, ENDGAME_EMPTIES)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SolveEndgame(positions[i%len(positions)])
+	}
+}
@@ -23,6 +23,9 @@ const PIECE_SLOTS = 64
 const FIRST_BLOCK = 11
 const LAST_BLOCK = 88
 
+// PASS - move of a player without any legal move
+const PASS = -1
+
 // DIRECTIONS
 var ALLDIRECTIONS = []int{-11, -10, -9, -1, 1, 9, 10, 11}
 
@@ -36,8 +39,10 @@ type OthelloBoardGameAction struct {
 type OthelloGameState struct {
 	nextToMove int
 	board      []int
-	ended      bool
-	result     gomcts.GameResult
+	// hash - Zobrist hash of board and nextToMove, kept up to date by ApplyTo
+	hash   uint64
+	ended  bool
+	result gomcts.GameResult
 }
 
 /*
@@ -58,7 +63,7 @@ func New(nextToMove int) OthelloGameState {
 	board[54] = BLUE
 	board[55] = RED
 
-	state := OthelloGameState{nextToMove: nextToMove, board: board}
+	state := OthelloGameState{nextToMove: nextToMove, board: board, hash: zobristHash(board, nextToMove)}
 	return state
 }
 
@@ -111,6 +116,10 @@ func (s OthelloGameState) EvaluateGame() (result gomcts.GameResult, ended bool)
 
 // ApplyTo - OthelloBoardGameAction implementation of ApplyTo method of Action interface
 func (a OthelloBoardGameAction) ApplyTo(s gomcts.GameState) gomcts.GameState {
+	if b, ok := s.(OthelloBitboardGameState); ok {
+		return a.applyBitboard(b)
+	}
+
 	g := s.(OthelloGameState)
 	board := make([]int, BOARD_SIZE)
 
@@ -121,6 +130,15 @@ func (a OthelloBoardGameAction) ApplyTo(s gomcts.GameState) gomcts.GameState {
 		panic("*hands slapped*,  not your turn")
 	}
 
+	if a.move == PASS {
+		if numLegalActions(g.board, g.nextToMove) != 0 {
+			panic("*hands slapped*,  can't pass with moves available")
+		}
+		g.nextToMove = opponent(a.value)
+		g.hash ^= zobristSide
+		return g
+	}
+
 	if g.board[a.move] != EMPTY {
 		panic("*hands slapped*,  square already occupied")
 	}
@@ -129,14 +147,10 @@ func (a OthelloBoardGameAction) ApplyTo(s gomcts.GameState) gomcts.GameState {
 		panic("*hands slapped*,  move out of bounds")
 	}
 
-	makeMove(a.move, g)
+	g.hash ^= makeMove(a.move, g)
 
 	g.nextToMove = opponent(a.value)
-
-	// Next to play has no moves
-	if numLegalActions(g.board, g.nextToMove) == 0 {
-		g.nextToMove = a.value
-	}
+	g.hash ^= zobristSide
 
 	return g
 }
@@ -155,6 +169,11 @@ func (s OthelloGameState) GetLegalActions() []gomcts.Action {
 		}
 	}
 
+	// No moves, the player passes unless the game is over
+	if cnt == 0 && numLegalActions(board, opponent(nextToMove)) != 0 {
+		actions = append(actions, OthelloBoardGameAction{move: PASS, value: s.nextToMove})
+	}
+
 	return actions
 }
 
@@ -178,10 +197,16 @@ func (s OthelloGameState) GetBoard() []int {
 func (s OthelloGameState) Clone() OthelloGameState {
 	board := make([]int, BOARD_SIZE)
 	copy(board, s.board)
-	state := OthelloGameState{nextToMove: s.nextToMove, board: board}
+	state := OthelloGameState{nextToMove: s.nextToMove, board: board, hash: s.hash}
 	return state
 }
 
+// Hash - OthelloGameState implementation of Hash method of Hashable interface,
+// the Zobrist hash of the squares and the player to move
+func (s OthelloGameState) Hash() uint64 {
+	return s.hash
+}
+
 // GetScore - Get the current score
 func (s OthelloGameState) GetScore() (p1, p2 int) {
 	return count(s.board, BLUE), count(s.board, RED)
@@ -191,11 +216,22 @@ func (s OthelloGameState) GetScore() (p1, p2 int) {
  * OthelloBoardGameAction custom methods
  */
 
-// GetMove - Get move field of
+// GetMove - Get move field of, PASS for a pass
 func (a OthelloBoardGameAction) GetMove() int {
 	return a.move
 }
 
+// ActionKey - OthelloBoardGameAction implementation of ActionKey method of
+// KeyedAction interface
+func (a OthelloBoardGameAction) ActionKey() uint64 {
+	return uint64(a.move)
+}
+
+// IsPass - Whether the action is a pass
+func (a OthelloBoardGameAction) IsPass() bool {
+	return a.move == PASS
+}
+
 // GetValue
 func (a OthelloBoardGameAction) GetValue() int {
 	return a.value
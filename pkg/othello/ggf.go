@@ -0,0 +1,235 @@
+package othello
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Games are saved in the Generic Game Format (GGF) of the GGS Othello
+// server, each game a list of properties KEY[value] between "(;" and ";)":
+//
+//	(;GM[Othello]PB[alice]PW[bob]TI[15:00]TY[8]RE[+4.000]
+//	BO[8 -------- -------- -------- ---O*--- ---*O--- -------- -------- -------- *]
+//	B[d3//1.20]W[c5/-2.00/0.80];)
+//
+// The board is written a row at a time with * for black (BLUE), O for white
+// (RED) and - for an empty square, followed by the side to move. Moves are
+// written as move/evaluation/time in seconds, the last two optional, and a
+// pass as PA.
+
+// GGF_PASS - GGF notation of a pass
+const GGF_PASS = "PA"
+
+// ReadGGF - read all the games of a GGF file
+func ReadGGF(r io.Reader) ([]GameRecord, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]GameRecord, 0)
+	text := string(data)
+	for {
+		start := strings.Index(text, "(;")
+		if start < 0 {
+			return records, nil
+		}
+		end := strings.Index(text[start:], ";)")
+		if end < 0 {
+			return nil, fmt.Errorf("othello: unterminated GGF game %v", len(records)+1)
+		}
+
+		record, err := parseGGF(text[start+2 : start+end])
+		if err != nil {
+			return nil, fmt.Errorf("othello: GGF game %v: %v", len(records)+1, err)
+		}
+		records = append(records, record)
+		text = text[start+end+2:]
+	}
+}
+
+// WriteGGF - write a game in GGF
+func WriteGGF(w io.Writer, record GameRecord) error {
+	b := bufio.NewWriter(w)
+
+	fmt.Fprint(b, "(;GM[Othello]")
+	properties := []struct{ key, value string }{
+		{"PC", record.Place},
+		{"DT", record.Date},
+		{"PB", record.Black},
+		{"PW", record.White},
+		{"RB", record.BlackRating},
+		{"RW", record.WhiteRating},
+		{"TI", record.TimeControl},
+	}
+	for _, p := range properties {
+		if p.value != "" {
+			fmt.Fprintf(b, "%s[%s]", p.key, p.value)
+		}
+	}
+	fmt.Fprint(b, "TY[8]")
+	if record.Result != "" {
+		fmt.Fprintf(b, "RE[%s]", record.Result)
+	}
+	fmt.Fprintf(b, "BO[%s]", ggfBoard(record.Start))
+
+	for _, move := range record.Moves {
+		color := "B"
+		if move.Player == RED {
+			color = "W"
+		}
+		fmt.Fprintf(b, "%s[%s]", color, ggfMove(move))
+	}
+	fmt.Fprint(b, ";)\n")
+
+	return b.Flush()
+}
+
+// parses the properties of a game
+func parseGGF(game string) (GameRecord, error) {
+	record := GameRecord{Start: New(BLUE)}
+
+	for {
+		game = strings.TrimSpace(game)
+		if game == "" {
+			return record, nil
+		}
+
+		open := strings.IndexByte(game, '[')
+		end := strings.IndexByte(game, ']')
+		if open <= 0 || end < open {
+			return record, fmt.Errorf("malformed property %q", game)
+		}
+		key, value := game[:open], game[open+1:end]
+		game = game[end+1:]
+
+		switch key {
+		case "GM":
+			if !strings.EqualFold(value, "othello") {
+				return record, fmt.Errorf("not an Othello game but %q", value)
+			}
+		case "PC":
+			record.Place = value
+		case "DT":
+			record.Date = value
+		case "PB":
+			record.Black = value
+		case "PW":
+			record.White = value
+		case "RB":
+			record.BlackRating = value
+		case "RW":
+			record.WhiteRating = value
+		case "TI":
+			record.TimeControl = value
+		case "RE":
+			record.Result = value
+		case "BO":
+			start, err := parseGGFBoard(value)
+			if err != nil {
+				return record, err
+			}
+			record.Start = start
+		case "B", "W":
+			move, err := parseGGFMove(value)
+			if err != nil {
+				return record, err
+			}
+			move.Player = BLUE
+			if key == "W" {
+				move.Player = RED
+			}
+			record.Moves = append(record.Moves, move)
+		}
+	}
+}
+
+// parses a board, its size, rows and side to move
+func parseGGFBoard(board string) (OthelloGameState, error) {
+	fields := strings.Fields(board)
+	if len(fields) < 2 || fields[0] != strconv.Itoa(BOARD_WIDTH) {
+		return OthelloGameState{}, fmt.Errorf("unsupported board %q", board)
+	}
+
+	squares := strings.Join(fields[1:len(fields)-1], "")
+	return ParsePosition(squares + " " + fields[len(fields)-1])
+}
+
+// formats a board, its size, rows and side to move
+func ggfBoard(s OthelloGameState) string {
+	position := strings.Replace(s.String(), string(BLUE_SYMBOL), "*", -1)
+
+	rows := make([]string, 0, BOARD_WIDTH+2)
+	rows = append(rows, strconv.Itoa(BOARD_WIDTH))
+	for row := 0; row < BOARD_WIDTH; row++ {
+		rows = append(rows, position[row*BOARD_WIDTH:(row+1)*BOARD_WIDTH])
+	}
+	rows = append(rows, position[PIECE_SLOTS+1:])
+	return strings.Join(rows, " ")
+}
+
+// parses a move with its optional evaluation and time
+func parseGGFMove(value string) (RecordMove, error) {
+	var move RecordMove
+	var err error
+
+	parts := strings.Split(value, "/")
+	if strings.EqualFold(parts[0], GGF_PASS) {
+		move.Move = PASS
+	} else if move.Move, err = ParseMove(parts[0]); err != nil {
+		return move, err
+	}
+
+	if len(parts) > 1 && parts[1] != "" {
+		if move.Eval, err = strconv.ParseFloat(parts[1], 64); err != nil {
+			return move, fmt.Errorf("invalid evaluation of move %q", value)
+		}
+	}
+
+	if len(parts) > 2 && parts[2] != "" {
+		if move.Time, err = parseGGFTime(parts[2]); err != nil {
+			return move, fmt.Errorf("invalid time of move %q", value)
+		}
+	}
+
+	return move, nil
+}
+
+// formats a move with its evaluation and time when known
+func ggfMove(move RecordMove) string {
+	notation := MoveString(move.Move)
+	if move.Move == PASS {
+		notation = GGF_PASS
+	}
+
+	eval := ""
+	if move.Eval != 0 {
+		eval = strconv.FormatFloat(move.Eval, 'f', 2, 64)
+	}
+
+	if move.Time != 0 {
+		return fmt.Sprintf("%s/%s/%.2f", notation, eval, move.Time.Seconds())
+	}
+	if eval != "" {
+		return notation + "/" + eval
+	}
+	return notation
+}
+
+// parses a time in seconds, minutes:seconds or hours:minutes:seconds
+func parseGGFTime(value string) (time.Duration, error) {
+	var seconds float64
+	for _, part := range strings.Split(value, ":") {
+		n, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return 0, err
+		}
+		seconds = 60*seconds + n
+	}
+	return time.Duration(math.Round(seconds * float64(time.Second))), nil
+}
@@ -0,0 +1,105 @@
+package othello
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const ggfGame = `(;GM[Othello]PC[GGS/os]DT[2003.12.15_13:24:03.MET]PB[alice]PW[bob]RB[2197.01]RW[2256.35]TI[15:00//02:00]TY[8]RE[+4.000]
+BO[8 -------- -------- -------- ---O*--- ---*O--- -------- -------- -------- *]
+B[d3//1.20]W[c5/-2.50/0.80]B[f6];)`
+
+func TestReadGGF(t *testing.T) {
+	records, err := ReadGGF(strings.NewReader(ggfGame + "\n" + ggfGame))
+	if err != nil {
+		t.Fatalf("game should be read but %v", err)
+	}
+
+	if len(records) != 2 {
+		t.Fatalf("there should be 2 games but there are %v", len(records))
+	}
+
+	record := records[0]
+	if record.Black != "alice" || record.White != "bob" || record.TimeControl != "15:00//02:00" || record.Result != "+4.000" {
+		t.Errorf("game properties read wrong, %+v", record)
+	}
+
+	if !reflect.DeepEqual(record.Start, New(BLUE)) {
+		t.Errorf("game should start from the initial position but starts from %v", record.Start)
+	}
+
+	want := []RecordMove{
+		{Move: 34, Player: BLUE, Time: 1200 * time.Millisecond},
+		{Move: 53, Player: RED, Eval: -2.5, Time: 800 * time.Millisecond},
+		{Move: 66, Player: BLUE},
+	}
+	if !reflect.DeepEqual(record.Moves, want) {
+		t.Errorf("moves should be %v but are %v", want, record.Moves)
+	}
+
+	if _, err := record.State(); err != nil {
+		t.Errorf("moves should be legal but %v", err)
+	}
+}
+
+func TestGGFRoundTrip(t *testing.T) {
+	records, _ := ReadGGF(strings.NewReader(ggfGame))
+
+	var b bytes.Buffer
+	if err := WriteGGF(&b, records[0]); err != nil {
+		t.Fatalf("game should be written but %v", err)
+	}
+
+	read, err := ReadGGF(&b)
+	if err != nil || len(read) != 1 {
+		t.Fatalf("written game should be read back but %v", err)
+	}
+
+	if !reflect.DeepEqual(read[0], records[0]) {
+		t.Errorf("game read back should be %+v but is %+v", records[0], read[0])
+	}
+}
+
+func TestGGFPass(t *testing.T) {
+	start, _ := ParsePosition("OX-------------------------------------------------------------- X")
+	record := NewRecord(start)
+	record.Moves = []RecordMove{{Move: PASS, Player: BLUE}, {Move: 13, Player: RED}}
+
+	var b bytes.Buffer
+	WriteGGF(&b, record)
+	if !strings.Contains(b.String(), "B[PA]") {
+		t.Errorf("pass should be written as PA in %v", b.String())
+	}
+
+	read, _ := ReadGGF(&b)
+	state, err := read[0].State()
+	if err != nil {
+		t.Fatalf("moves should be legal but %v", err)
+	}
+
+	if !state.IsGameEnded() {
+		t.Errorf("game should be over after red takes b1")
+	}
+}
+
+func TestGGFIllegalMove(t *testing.T) {
+	records, err := ReadGGF(strings.NewReader("(;GM[Othello]B[a1];)"))
+	if err != nil {
+		t.Fatalf("game should be read but %v", err)
+	}
+
+	if _, err := records[0].Actions(); err == nil {
+		t.Errorf("a1 should not be a legal first move")
+	}
+}
+
+func TestReadMalformedGGF(t *testing.T) {
+	for _, game := range []string{"(;GM[Othello]B[d3]", "(;GM[Chess];)", "(;GM[Othello]B[z9];)", "(;GM[Othello]BO[10 -];)"} {
+		if _, err := ReadGGF(strings.NewReader(game)); err == nil {
+			t.Errorf("%q should not be read", game)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package othello
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Moves are written in the standard Othello coordinates, a column letter a-h
+// from left to right followed by a row number 1-8 from top to bottom, so the
+// mailbox square col + 10*row is written as column col and row row, e.g. 34
+// is d3. A pass is written as "pass".
+
+// PASS_NOTATION - notation of a pass
+const PASS_NOTATION = "pass"
+
+// NewAction - initializes a new OthelloBoardGameAction of player value on
+// square move, PASS for a pass
+func NewAction(move, value int) OthelloBoardGameAction {
+	return OthelloBoardGameAction{move: move, value: value}
+}
+
+// ParseMove - Get the mailbox square of a move in standard notation, e.g. 34
+// for "d3" and PASS for "pass"
+func ParseMove(notation string) (int, error) {
+	notation = strings.ToLower(strings.TrimSpace(notation))
+	if notation == PASS_NOTATION {
+		return PASS, nil
+	}
+
+	if len(notation) != 2 || notation[0] < 'a' || notation[0] > 'h' || notation[1] < '1' || notation[1] > '8' {
+		return 0, fmt.Errorf("othello: invalid move %q", notation)
+	}
+
+	return Square(int(notation[0]-'a'), int(notation[1]-'1')), nil
+}
+
+// MoveString - Get the standard notation of mailbox square move
+func MoveString(move int) string {
+	if move == PASS {
+		return PASS_NOTATION
+	}
+	col, row := Coordinates(move)
+	return fmt.Sprintf("%c%d", 'a'+col, row+1)
+}
+
+// Square - Get the mailbox square of the zero based column and row
+func Square(col, row int) int {
+	return col + 1 + 10*(row+1)
+}
+
+// Coordinates - Get the zero based column and row of mailbox square move
+func Coordinates(move int) (col, row int) {
+	return move%10 - 1, move/10 - 1
+}
+
+// String - Get the standard notation of the action
+func (a OthelloBoardGameAction) String() string {
+	return MoveString(a.move)
+}
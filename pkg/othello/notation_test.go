@@ -0,0 +1,46 @@
+package othello
+
+import (
+	"testing"
+)
+
+func TestParseMove(t *testing.T) {
+	moves := map[string]int{"a1": 11, "h1": 18, "d3": 34, "a8": 81, "h8": 88, "E6": 65, "pass": PASS}
+
+	for notation, want := range moves {
+		move, err := ParseMove(notation)
+		if err != nil || move != want {
+			t.Errorf("%q should parse to %v but parses to %v, %v", notation, want, move, err)
+		}
+	}
+}
+
+func TestParseInvalidMove(t *testing.T) {
+	for _, notation := range []string{"", "d", "i1", "a0", "a9", "d33", "34"} {
+		if _, err := ParseMove(notation); err == nil {
+			t.Errorf("%q should not parse", notation)
+		}
+	}
+}
+
+func TestMoveStringRoundTrip(t *testing.T) {
+	for row := 0; row < BOARD_WIDTH; row++ {
+		for col := 0; col < BOARD_WIDTH; col++ {
+			move := Square(col, row)
+			if parsed, err := ParseMove(MoveString(move)); err != nil || parsed != move {
+				t.Errorf("%v should parse back to %v but parses to %v, %v", MoveString(move), move, parsed, err)
+			}
+		}
+	}
+}
+
+func TestInitialLegalActionsNotation(t *testing.T) {
+	want := []string{"d3", "c4", "f5", "e6"}
+	actions := New(BLUE).GetLegalActions()
+
+	for i, action := range actions {
+		if action.(OthelloBoardGameAction).String() != want[i] {
+			t.Errorf("legal action %v should be %v but is %v", i, want[i], action)
+		}
+	}
+}
@@ -119,6 +119,36 @@ func TestAlreadyOccupiedSquareMovePanic(t *testing.T) {
 	action.ApplyTo(state)
 }
 
+func TestPassWithMovesPanic(t *testing.T) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Errorf("The code did not panic but should")
+		}
+	}()
+
+	state := New(1)
+	action := OthelloBoardGameAction{move: PASS, value: 1}
+	action.ApplyTo(state)
+}
+
+func TestPassIsOnlyActionWithoutMoves(t *testing.T) {
+	// blue can't move, red can play c1 over b1
+	state, _ := ParsePosition("OX-------------------------------------------------------------- X")
+
+	for _, s := range []gomcts.GameState{state, state.Bitboard()} {
+		actions := s.GetLegalActions()
+		if len(actions) != 1 || !actions[0].(OthelloBoardGameAction).IsPass() {
+			t.Fatalf("blue should only be able to pass but can play %v", actions)
+		}
+
+		next := actions[0].ApplyTo(s)
+		if next.NextToMove() != RED {
+			t.Errorf("red should move after blue passes but %v moves", next.NextToMove())
+		}
+	}
+}
+
 func TestGameEvaluationShouldBeNotEnded(t *testing.T) {
 	state := New(1)
 
@@ -222,3 +252,33 @@ func TestGameEvaluationShouldResultRedWinning(t *testing.T) {
 	}
 
 }
+
+func TestEqualStatesHashEqual(t *testing.T) {
+	state := New(BLUE)
+	next := OthelloBoardGameAction{move: 34, value: BLUE}.ApplyTo(state).(OthelloGameState)
+
+	if state.Hash() != state.Clone().Hash() {
+		t.Errorf("equal states should hash equal")
+	}
+
+	if state.Hash() == New(RED).Hash() {
+		t.Errorf("states with different players to move should hash differently")
+	}
+
+	if state.Hash() == next.Hash() {
+		t.Errorf("states with different boards should hash differently")
+	}
+}
+
+func TestHardRolloutPolicyTakesCorner(t *testing.T) {
+	state, err := ParsePosition("-OX------------------------OX------XO--------------------------- X")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, policy := range []gomcts.RolloutPolicy{OthelloHardRolloutPolicy, HeuristicRolloutPolicy(HARD_WEIGHTS)} {
+		if action := policy(state, nil).(OthelloBoardGameAction); action.String() != "a1" {
+			t.Errorf("policy should take the corner a1 but plays %v", action)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package othello
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/unathi-skosana/gothello/pkg/gomcts"
+)
+
+// The pattern evaluation scores a position by looking up the contents of
+// groups of squares, the patterns, in tables of weights. Each pattern is
+// scored on every symmetric instance of it on the board with the same table,
+// reading its squares in each order a symmetry gives them so symmetric
+// positions score the same, and there is a set of tables per phase of the
+// game, by the discs on the board. The contents of the squares of an
+// instance, in order, make a base 3 index: 0 for an empty square, 1 for a
+// disc of the player to move and 2 for one of the opponent.
+//
+// Weights are stored as text, a line per weight that isn't zero:
+//
+//	3 edge+2x 29524 -12.5
+//
+// the phase, the pattern name, the index and the weight. Lines starting with
+// # are comments.
+
+// PATTERN_PHASES - game phases with their own weights
+const PATTERN_PHASES = 6
+
+// Pattern - squares of the board whose contents are scored together
+type Pattern struct {
+	Name string
+	// Instances - mailbox squares of each symmetric instance of the pattern,
+	// in each distinct order
+	Instances [][]int
+}
+
+// PATTERNS - patterns of the pattern evaluation
+var PATTERNS = []Pattern{
+	newPattern("edge+2x", "a1 b1 c1 d1 e1 f1 g1 h1 b2 g2"),
+	newPattern("corner3x3", "a1 b1 c1 a2 b2 c2 a3 b3 c3"),
+	newPattern("corner2x5", "a1 b1 c1 d1 e1 a2 b2 c2 d2 e2"),
+	newPattern("diagonal8", "a1 b2 c3 d4 e5 f6 g7 h8"),
+	newPattern("diagonal7", "b1 c2 d3 e4 f5 g6 h7"),
+	newPattern("diagonal6", "c1 d2 e3 f4 g5 h6"),
+	newPattern("diagonal5", "d1 e2 f3 g4 h5"),
+	newPattern("diagonal4", "e1 f2 g3 h4"),
+}
+
+// newPattern - pattern of the squares in notation and their distinct
+// symmetric instances. Symmetries taking the squares to themselves in
+// another order, like the one swapping columns a and h for the edge, give
+// instances of their own.
+func newPattern(name, notation string) Pattern {
+	squares := make([]int, 0)
+	for _, field := range strings.Fields(notation) {
+		move, err := ParseMove(field)
+		if err != nil {
+			panic(err)
+		}
+		squares = append(squares, move)
+	}
+
+	pattern := Pattern{Name: name}
+	seen := make(map[string]bool)
+	for t := 0; t < SYMMETRIES; t++ {
+		instance := make([]int, len(squares))
+		for i, square := range squares {
+			instance[i] = TransformMove(t, square)
+		}
+		if key := fmt.Sprint(instance); !seen[key] {
+			seen[key] = true
+			pattern.Instances = append(pattern.Instances, instance)
+		}
+	}
+	return pattern
+}
+
+// Size - Get the number of configurations of the squares of the pattern
+func (p Pattern) Size() int {
+	size := 1
+	for range p.Instances[0] {
+		size *= 3
+	}
+	return size
+}
+
+// PatternWeights - weights of the configurations of each pattern in each
+// game phase
+type PatternWeights struct {
+	// tables - weight of each index of each pattern in each phase
+	tables [PATTERN_PHASES][][]float64
+}
+
+// NewPatternWeights - initializes new PatternWeights, all zero
+func NewPatternWeights() *PatternWeights {
+	w := &PatternWeights{}
+	for phase := range w.tables {
+		w.tables[phase] = make([][]float64, len(PATTERNS))
+		for p, pattern := range PATTERNS {
+			w.tables[phase][p] = make([]float64, pattern.Size())
+		}
+	}
+	return w
+}
+
+// PatternPhase - Get the game phase of the game state, by the discs on the
+// board
+func PatternPhase(s OthelloGameState) int {
+	discs := PIECE_SLOTS - s.Empties()
+	return (discs - 4) * PATTERN_PHASES / (PIECE_SLOTS - 3)
+}
+
+// PatternIndex - Get the index of the contents of squares in the game state
+func PatternIndex(s OthelloGameState, squares []int) int {
+	index := 0
+	for _, square := range squares {
+		index *= 3
+		switch s.board[square] {
+		case EMPTY:
+		case s.nextToMove:
+			index++
+		default:
+			index += 2
+		}
+	}
+	return index
+}
+
+// Weight - Get the weight of index of pattern p in phase
+func (w *PatternWeights) Weight(phase, p, index int) float64 {
+	return w.tables[phase][p][index]
+}
+
+// SetWeight - Set the weight of index of pattern p in phase
+func (w *PatternWeights) SetWeight(phase, p, index int, weight float64) {
+	w.tables[phase][p][index] = weight
+}
+
+// Evaluate - Get the score of the game state for the player to move, the sum
+// of the weights of every instance of every pattern
+func (w *PatternWeights) Evaluate(s OthelloGameState) float64 {
+	tables := w.tables[PatternPhase(s)]
+
+	score := 0.0
+	for p, pattern := range PATTERNS {
+		for _, instance := range pattern.Instances {
+			score += tables[p][PatternIndex(s, instance)]
+		}
+	}
+	return score
+}
+
+// PatternEvaluator - Evaluator scoring game states with w
+func PatternEvaluator(w *PatternWeights) Evaluator {
+	return w.Evaluate
+}
+
+// EvaluatorRolloutPolicy - rollout policy playing the action leaving the
+// opponent the lowest score according to evaluator
+func EvaluatorRolloutPolicy(evaluator Evaluator) gomcts.RolloutPolicy {
+	return func(state gomcts.GameState, rng *rand.Rand) gomcts.Action {
+		actions := state.GetLegalActions()
+		if len(actions) == 1 {
+			return actions[0]
+		}
+
+		s := mailbox(state)
+		best := 0
+		bestScore := math.Inf(1)
+		for i, action := range actions {
+			if score := evaluator(action.ApplyTo(s).(OthelloGameState)); score < bestScore {
+				best, bestScore = i, score
+			}
+		}
+		return actions[best]
+	}
+}
+
+// Save - write the weights in their text format
+func (w *PatternWeights) Save(wr io.Writer) error {
+	buf := bufio.NewWriter(wr)
+	for phase := range w.tables {
+		for p, pattern := range PATTERNS {
+			for index, weight := range w.tables[phase][p] {
+				if weight != 0 {
+					fmt.Fprintf(buf, "%d %s %d %s\n", phase, pattern.Name, index, strconv.FormatFloat(weight, 'g', -1, 64))
+				}
+			}
+		}
+	}
+	return buf.Flush()
+}
+
+// LoadPatternWeights - read weights written by Save, weights missing from
+// the file are zero
+func LoadPatternWeights(r io.Reader) (*PatternWeights, error) {
+	w := NewPatternWeights()
+	patterns := make(map[string]int, len(PATTERNS))
+	for p, pattern := range PATTERNS {
+		patterns[pattern.Name] = p
+	}
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("othello: weights line %v: expected phase, pattern, index and weight", n)
+		}
+
+		phase, err := strconv.Atoi(fields[0])
+		if err != nil || phase < 0 || phase >= PATTERN_PHASES {
+			return nil, fmt.Errorf("othello: weights line %v: invalid phase %q", n, fields[0])
+		}
+		p, ok := patterns[fields[1]]
+		if !ok {
+			return nil, fmt.Errorf("othello: weights line %v: unknown pattern %q", n, fields[1])
+		}
+		index, err := strconv.Atoi(fields[2])
+		if err != nil || index < 0 || index >= PATTERNS[p].Size() {
+			return nil, fmt.Errorf("othello: weights line %v: invalid index %q", n, fields[2])
+		}
+		weight, err := strconv.ParseFloat(fields[3], 64)
+		if err != nil || math.IsNaN(weight) || math.IsInf(weight, 0) {
+			return nil, fmt.Errorf("othello: weights line %v: invalid weight %q", n, fields[3])
+		}
+
+		w.tables[phase][p][index] = weight
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
@@ -0,0 +1,131 @@
+package othello
+
+import (
+	"bytes"
+	"math"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPatternInstances(t *testing.T) {
+	want := map[string]int{
+		"edge+2x": 8, "corner3x3": 8, "corner2x5": 8, "diagonal8": 4,
+		"diagonal7": 8, "diagonal6": 8, "diagonal5": 8, "diagonal4": 8,
+	}
+
+	for _, pattern := range PATTERNS {
+		if len(pattern.Instances) != want[pattern.Name] {
+			t.Errorf("pattern %v should have %v instances but has %v", pattern.Name, want[pattern.Name], len(pattern.Instances))
+		}
+		for _, instance := range pattern.Instances {
+			for _, square := range instance {
+				if !bound(square) {
+					t.Errorf("pattern %v has square %v off the board", pattern.Name, square)
+				}
+			}
+		}
+	}
+}
+
+func TestPatternEvaluate(t *testing.T) {
+	w := NewPatternWeights()
+	state, err := ParsePosition("X--------------------------OX------XO--------------------------- O")
+	if err != nil {
+		t.Fatal(err)
+	}
+	phase := PatternPhase(state)
+
+	// a1, held by the opponent of the player to move, is the first square of
+	// the edges along row 1 and column a and of the 3x3 corner read by rows
+	// and by columns
+	w.SetWeight(phase, 0, 2*59049/3, -40)
+	w.SetWeight(phase, 1, 2*19683/3, -10)
+
+	if score := w.Evaluate(state); score != -100 {
+		t.Errorf("score should be -100 but is %v", score)
+	}
+	if score := PatternEvaluator(w)(New(BLUE)); score != 0 {
+		t.Errorf("score of a position without weights should be 0 but is %v", score)
+	}
+}
+
+func TestPatternEvaluateSymmetries(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	w := NewPatternWeights()
+	for phase := 0; phase < PATTERN_PHASES; phase++ {
+		for p, pattern := range PATTERNS {
+			for index := 0; index < pattern.Size(); index++ {
+				w.SetWeight(phase, p, index, rng.NormFloat64())
+			}
+		}
+	}
+
+	for i := 0; i < 20; i++ {
+		s := randomPosition(rng, 1+rng.Intn(55)).Mailbox()
+		score := w.Evaluate(s)
+		for sym := 1; sym < SYMMETRIES; sym++ {
+			if transformed := w.Evaluate(s.Transform(sym)); math.Abs(transformed-score) > 1e-9 {
+				t.Errorf("score of %v under symmetry %v should be %v but is %v", s, sym, score, transformed)
+			}
+		}
+	}
+}
+
+func TestPatternWeightsSaveLoad(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	w := NewPatternWeights()
+	for i := 0; i < 100; i++ {
+		p := rng.Intn(len(PATTERNS))
+		w.SetWeight(rng.Intn(PATTERN_PHASES), p, rng.Intn(PATTERNS[p].Size()), rng.NormFloat64())
+	}
+
+	var buf bytes.Buffer
+	if err := w.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadPatternWeights(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(w, loaded) {
+		t.Errorf("loaded weights should equal the saved weights")
+	}
+}
+
+func TestLoadPatternWeightsErrors(t *testing.T) {
+	for _, weights := range []string{
+		"0 edge+2x 1",
+		"6 edge+2x 1 1.5",
+		"0 edge 1 1.5",
+		"0 diagonal4 81 1.5",
+		"0 diagonal4 1 NaN",
+	} {
+		if _, err := LoadPatternWeights(strings.NewReader("# weights\n" + weights)); err == nil {
+			t.Errorf("weights %q should be rejected", weights)
+		}
+	}
+}
+
+func TestEvaluatorRolloutPolicy(t *testing.T) {
+	// d3 leaves the opponent the lowest score
+	policy := EvaluatorRolloutPolicy(func(s OthelloGameState) float64 {
+		if s.board[34] != EMPTY {
+			return -1
+		}
+		return 0
+	})
+	if action := policy(NewBitboard(BLUE), nil).(OthelloBoardGameAction); action.String() != "d3" {
+		t.Errorf("policy should play d3 but plays %v", action)
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	patterns := EvaluatorRolloutPolicy(PatternEvaluator(NewPatternWeights()))
+	for i := 0; i < 20; i++ {
+		s := randomPosition(rng, 20+rng.Intn(30))
+		if action := patterns(s, rng); !IsLegalAction(s.Mailbox(), action.(OthelloBoardGameAction)) {
+			t.Errorf("policy played the illegal action %v in %v", action, s)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package othello
+
+import (
+	"github.com/unathi-skosana/gothello/pkg/gomcts"
+)
+
+// Perft - count the positions reached after exactly depth plies from state,
+// using only the GameState interface so that it checks GetLegalActions and
+// ApplyTo of either representation. A pass counts as a ply and a finished
+// game counts as a position at every depth past its end, which gives the
+// published Othello perft numbers from the initial position.
+func Perft(state gomcts.GameState, depth int) uint64 {
+	if depth == 0 || state.IsGameEnded() {
+		return 1
+	}
+
+	var nodes uint64
+	for _, action := range state.GetLegalActions() {
+		nodes += Perft(action.ApplyTo(state), depth-1)
+	}
+	return nodes
+}
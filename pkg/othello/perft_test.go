@@ -0,0 +1,34 @@
+package othello
+
+import (
+	"testing"
+)
+
+// published perft numbers from the initial position, passes count as a ply
+var perftNodes = []uint64{1, 4, 12, 56, 244, 1396, 8200, 55092, 390216, 3005288, 24571284}
+
+func TestPerftMailbox(t *testing.T) {
+	depth := 8
+	if testing.Short() {
+		depth = 6
+	}
+
+	for d := 0; d <= depth; d++ {
+		if nodes := Perft(New(BLUE), d); nodes != perftNodes[d] {
+			t.Errorf("perft(%v) should be %v but is %v", d, perftNodes[d], nodes)
+		}
+	}
+}
+
+func TestPerftBitboard(t *testing.T) {
+	depth := len(perftNodes) - 1
+	if testing.Short() {
+		depth = 8
+	}
+
+	for d := 0; d <= depth; d++ {
+		if nodes := Perft(NewBitboard(BLUE), d); nodes != perftNodes[d] {
+			t.Errorf("perft(%v) should be %v but is %v", d, perftNodes[d], nodes)
+		}
+	}
+}
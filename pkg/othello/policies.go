@@ -1,14 +1,13 @@
 package othello
 
 import (
-	"crypto/rand"
-	"math/big"
+	"math/rand"
 
 	"github.com/unathi-skosana/gothello/pkg/gomcts"
 )
 
 // OthelloRandomRolloutPolicy - Randomly select next move
-func OthelloRandomRolloutPolicy(state gomcts.GameState) gomcts.Action {
+func OthelloRandomRolloutPolicy(state gomcts.GameState, rng *rand.Rand) gomcts.Action {
 	actions := state.GetLegalActions()
 	numberOfActions := len(actions)
 
@@ -16,78 +15,37 @@ func OthelloRandomRolloutPolicy(state gomcts.GameState) gomcts.Action {
 		return actions[0]
 	}
 
-	actionIndex, _ := rand.Int(rand.Reader, big.NewInt(int64(numberOfActions)))
-	return actions[actionIndex.Int64()]
+	return actions[rng.Intn(numberOfActions)]
 
 }
 
 // OthelloMediumRolloutPolicy - Evaluate moves with evaluation function and
-// select one with max evaluation score with equally weighted heuristics
-func OthelloMediumRolloutPolicy(state gomcts.GameState) gomcts.Action {
-	actions := state.GetLegalActions()
-	scores := make([]float64, 0)
-	dummyGameState := state.(OthelloGameState)
-	numberOfActions := len(actions)
-
-	parityWeight := 25.00
-	mobilityWeight := 25.00
-	cornersWeight := 25.00
-	frontiersWeight := 25.00
-
-	if numberOfActions == 1 {
-		return actions[0]
-	}
-
-	for i := 0; i < numberOfActions; i++ {
-		cur := actions[i].ApplyTo(dummyGameState.Clone())
-		scores = append(scores, evaluate(cur.(OthelloGameState), parityWeight, mobilityWeight, cornersWeight, frontiersWeight))
-	}
-
-	maxIndex := 0
-	maxValue := scores[0]
-
-	for i := 1; i < numberOfActions; i++ {
-		if scores[i] > maxValue {
-			maxValue = scores[i]
-			maxIndex = i
-		}
-	}
-
-	return actions[maxIndex]
+// select the one leaving the opponent the lowest score with equally weighted
+// heuristics, MEDIUM_WEIGHTS
+func OthelloMediumRolloutPolicy(state gomcts.GameState, rng *rand.Rand) gomcts.Action {
+	return mediumRolloutPolicy(state, rng)
 }
 
-// OthelloHardRolloutPolicy - Evaluate moves with evaluation function and select
-// one with max evaluation score with heuristic weights I've found to work quite
-// work.
-func OthelloHardRolloutPolicy(state gomcts.GameState) gomcts.Action {
-	actions := state.GetLegalActions()
-	scores := make([]float64, 0)
-	dummyGameState := state.(OthelloGameState)
-	numberOfActions := len(actions)
-
-	parityWeight := 21.45
-	mobilityWeight := 3.37
-	cornersWeight := 70.00
-	frontiersWeight := 5.38
-
-	if numberOfActions == 1 {
-		return actions[0]
-	}
+// OthelloHardRolloutPolicy - Evaluate moves with evaluation function and
+// select the one leaving the opponent the lowest score with HARD_WEIGHTS
+func OthelloHardRolloutPolicy(state gomcts.GameState, rng *rand.Rand) gomcts.Action {
+	return hardRolloutPolicy(state, rng)
+}
 
-	for i := 0; i < numberOfActions; i++ {
-		cur := actions[i].ApplyTo(dummyGameState.Clone())
-		scores = append(scores, evaluate(cur.(OthelloGameState), parityWeight, mobilityWeight, cornersWeight, frontiersWeight))
-	}
+// policies of the built-in weights, made once
+var mediumRolloutPolicy = HeuristicRolloutPolicy(MEDIUM_WEIGHTS)
+var hardRolloutPolicy = HeuristicRolloutPolicy(HARD_WEIGHTS)
 
-	maxIndex := 0
-	maxValue := scores[0]
+// HeuristicRolloutPolicy - rollout policy playing the action leaving the
+// opponent the lowest evaluation with weights w
+func HeuristicRolloutPolicy(w Weights) gomcts.RolloutPolicy {
+	return EvaluatorRolloutPolicy(HeuristicEvaluator(w))
+}
 
-	for i := 1; i < numberOfActions; i++ {
-		if scores[i] > maxValue {
-			maxValue = scores[i]
-			maxIndex = i
-		}
+// gets the OthelloGameState of either representation of a game state
+func mailbox(state gomcts.GameState) OthelloGameState {
+	if b, ok := state.(OthelloBitboardGameState); ok {
+		return b.Mailbox()
 	}
-
-	return actions[maxIndex]
+	return state.(OthelloGameState)
 }
@@ -0,0 +1,93 @@
+package othello
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Positions are written in the Edax/NBoard obj style, 64 characters for the
+// squares a1, b1, ..., h8 followed by the side to move, separated by a space:
+// X for BLUE (black), O for RED (white) and - for an empty square, e.g. the
+// initial position is
+//
+//	---------------------------OX------XO--------------------------- X
+
+// position characters
+const (
+	BLUE_SYMBOL  = 'X'
+	RED_SYMBOL   = 'O'
+	EMPTY_SYMBOL = '-'
+)
+
+// ParsePosition - initializes the OthelloGameState of a position string,
+// also accepting * for BLUE, . for empty squares and the ; Edax ends its
+// positions with
+func ParsePosition(position string) (OthelloGameState, error) {
+	fields := strings.Fields(strings.TrimSuffix(strings.TrimSpace(position), ";"))
+	if len(fields) == 1 && len(fields[0]) == PIECE_SLOTS+1 {
+		// side to move written right after the squares
+		fields = []string{fields[0][:PIECE_SLOTS], fields[0][PIECE_SLOTS:]}
+	}
+
+	if len(fields) != 2 || len(fields[0]) != PIECE_SLOTS || len(fields[1]) != 1 {
+		return OthelloGameState{}, fmt.Errorf("othello: position %q should be %v squares and the side to move", position, PIECE_SLOTS)
+	}
+
+	board := make([]int, BOARD_SIZE)
+	for i, c := range []byte(fields[0]) {
+		piece, ok := positionPiece(c)
+		if !ok {
+			return OthelloGameState{}, fmt.Errorf("othello: invalid square %q in position %q", c, position)
+		}
+		board[Square(i%BOARD_WIDTH, i/BOARD_WIDTH)] = piece
+	}
+
+	nextToMove, ok := positionPiece(fields[1][0])
+	if !ok || nextToMove == EMPTY {
+		return OthelloGameState{}, fmt.Errorf("othello: invalid side to move %q in position %q", fields[1], position)
+	}
+
+	return OthelloGameState{nextToMove: nextToMove, board: board, hash: zobristHash(board, nextToMove)}, nil
+}
+
+// String - Get the position string of the game state
+func (s OthelloGameState) String() string {
+	var b strings.Builder
+	for row := 0; row < BOARD_WIDTH; row++ {
+		for col := 0; col < BOARD_WIDTH; col++ {
+			b.WriteByte(positionSymbol(s.board[Square(col, row)]))
+		}
+	}
+	b.WriteByte(' ')
+	b.WriteByte(positionSymbol(s.nextToMove))
+	return b.String()
+}
+
+// String - Get the position string of the game state
+func (s OthelloBitboardGameState) String() string {
+	return s.Mailbox().String()
+}
+
+// piece of a position character
+func positionPiece(c byte) (int, bool) {
+	switch c {
+	case 'X', 'x', '*':
+		return BLUE, true
+	case 'O', 'o':
+		return RED, true
+	case '-', '.':
+		return EMPTY, true
+	}
+	return EMPTY, false
+}
+
+// position character of a piece
+func positionSymbol(piece int) byte {
+	switch piece {
+	case BLUE:
+		return BLUE_SYMBOL
+	case RED:
+		return RED_SYMBOL
+	}
+	return EMPTY_SYMBOL
+}
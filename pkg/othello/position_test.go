@@ -0,0 +1,79 @@
+package othello
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+const initialPosition = "---------------------------OX------XO--------------------------- X"
+
+func TestInitialPositionString(t *testing.T) {
+	if New(BLUE).String() != initialPosition {
+		t.Errorf("initial position should be %v but is %v", initialPosition, New(BLUE))
+	}
+
+	if NewBitboard(BLUE).String() != initialPosition {
+		t.Errorf("initial position should be %v but is %v", initialPosition, NewBitboard(BLUE))
+	}
+}
+
+func TestParseInitialPosition(t *testing.T) {
+	state, err := ParsePosition(initialPosition)
+	if err != nil {
+		t.Fatalf("initial position should parse but %v", err)
+	}
+
+	if !reflect.DeepEqual(state, New(BLUE)) {
+		t.Errorf("parsed initial position should equal New(BLUE)")
+	}
+}
+
+func TestParsePositionVariants(t *testing.T) {
+	positions := []string{
+		"---------------------------OX------XO---------------------------X",
+		"...........................ox......xo........................... *",
+		// as written by Edax
+		"---------------------------OX------XO--------------------------- X;",
+		"---------------------------OX------XO--------------------------- X ;",
+	}
+
+	for _, position := range positions {
+		state, err := ParsePosition(position)
+		if err != nil || !reflect.DeepEqual(state, New(BLUE)) {
+			t.Errorf("%q should parse to the initial position but %v", position, err)
+		}
+	}
+}
+
+func TestPositionRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	state := New(BLUE)
+
+	for !state.IsGameEnded() {
+		parsed, err := ParsePosition(state.String())
+		if err != nil || !reflect.DeepEqual(parsed, state) {
+			t.Fatalf("%v should parse back to the same state but %v", state, err)
+		}
+
+		actions := state.GetLegalActions()
+		state = actions[rng.Intn(len(actions))].ApplyTo(state).(OthelloGameState)
+	}
+}
+
+func TestParseInvalidPosition(t *testing.T) {
+	positions := []string{
+		"",
+		"---------------------------OX------XO---------------------------",
+		"---------------------------OX------XO-------------------------- X",
+		"---------------------------OX------XO--------------------------? X",
+		"---------------------------OX------XO--------------------------- -",
+		"---------------------------OX------XO--------------------------- X;;",
+	}
+
+	for _, position := range positions {
+		if _, err := ParsePosition(position); err == nil {
+			t.Errorf("%q should not parse", position)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package othello
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/unathi-skosana/gothello/pkg/gomcts"
+)
+
+// GameRecord - record of a game, the position it started from and the moves
+// played from there
+type GameRecord struct {
+	Black       string
+	White       string
+	BlackRating string
+	WhiteRating string
+	Place       string
+	Date        string
+	TimeControl string
+	Start       OthelloGameState
+	Moves       []RecordMove
+	// Result - final disc difference from BLUE's (black's) point of view,
+	// as written by the record format, empty for an unfinished game
+	Result string
+}
+
+// RecordMove - move of a GameRecord with the evaluation and time spent on it
+// by the player, zero when unknown
+type RecordMove struct {
+	Move   int
+	Player int
+	Eval   float64
+	Time   time.Duration
+}
+
+// NewRecord - initializes a new GameRecord of a game started from start
+func NewRecord(start OthelloGameState) GameRecord {
+	return GameRecord{Start: start.Clone()}
+}
+
+// Actions - Get the actions of the moves of the record, checking that each
+// is legal in the position it's played in
+func (r GameRecord) Actions() ([]OthelloBoardGameAction, error) {
+	state := r.Start.Clone()
+	actions := make([]OthelloBoardGameAction, 0, len(r.Moves))
+
+	for i, move := range r.Moves {
+		action := OthelloBoardGameAction{move: move.Move, value: move.Player}
+		if !IsLegalAction(state, action) {
+			return nil, fmt.Errorf("othello: illegal move %v %v at move %v", playerName(move.Player), action, i+1)
+		}
+		actions = append(actions, action)
+		state = action.ApplyTo(state).(OthelloGameState)
+	}
+
+	return actions, nil
+}
+
+// State - Get the game state reached by playing the moves of the record
+func (r GameRecord) State() (OthelloGameState, error) {
+	actions, err := r.Actions()
+	if err != nil {
+		return OthelloGameState{}, err
+	}
+
+	state := r.Start.Clone()
+	for _, action := range actions {
+		state = action.ApplyTo(state).(OthelloGameState)
+	}
+	return state, nil
+}
+
+// Finish - set the result of the record from the final state of the game,
+// leaving it empty while the game is not over
+func (r *GameRecord) Finish(state OthelloGameState) {
+	if !state.IsGameEnded() {
+		r.Result = ""
+		return
+	}
+
+	blue, red := state.GetScore()
+	if blue == red {
+		r.Result = "0.000"
+	} else {
+		r.Result = fmt.Sprintf("%+.3f", float64(blue-red))
+	}
+}
+
+// Winner - Get the winner of the record, from its final position when the
+// game is over and from its result otherwise, EMPTY for a draw
+func (r GameRecord) Winner() (gomcts.GameResult, error) {
+	state, err := r.State()
+	if err != nil {
+		return 0, err
+	}
+	if result, ended := state.EvaluateGame(); ended {
+		return result, nil
+	}
+
+	// results may carry how the game ended, e.g. +12.000:r for a resignation
+	diff, err := strconv.ParseFloat(strings.SplitN(r.Result, ":", 2)[0], 64)
+	if err != nil {
+		return 0, fmt.Errorf("othello: game has no result")
+	}
+
+	if diff > 0 {
+		return gomcts.GameResult(BLUE), nil
+	} else if diff < 0 {
+		return gomcts.GameResult(RED), nil
+	}
+	return gomcts.GameResult(EMPTY), nil
+}
+
+// IsLegalAction - whether action is one of the legal actions of state
+func IsLegalAction(state gomcts.GameState, action OthelloBoardGameAction) bool {
+	for _, legal := range state.GetLegalActions() {
+		if legal == action {
+			return true
+		}
+	}
+	return false
+}
+
+// name of a player in messages
+func playerName(player int) string {
+	switch player {
+	case BLUE:
+		return "black"
+	case RED:
+		return "white"
+	}
+	return fmt.Sprintf("player %v", player)
+}
@@ -0,0 +1,94 @@
+package othello
+
+import (
+	"time"
+)
+
+// Session - game played from a start position, keeping the actions played
+// and the states they led to so that they can be undone and redone
+type Session struct {
+	// states[i] is the state after the first i moves
+	states  []OthelloGameState
+	moves   []RecordMove
+	current int
+}
+
+// NewSession - initializes a new Session of a game started from start
+func NewSession(start OthelloGameState) *Session {
+	return &Session{states: []OthelloGameState{start.Clone()}}
+}
+
+// State - Get the current game state
+func (s *Session) State() OthelloGameState {
+	return s.states[s.current]
+}
+
+// Play - play action, which spent time was spent on, in the current state.
+// Moves undone before are forgotten.
+func (s *Session) Play(action OthelloBoardGameAction, spent time.Duration) {
+	next := action.ApplyTo(s.State()).(OthelloGameState)
+
+	s.states = append(s.states[:s.current+1], next)
+	s.moves = append(s.moves[:s.current], RecordMove{Move: action.move, Player: action.value, Time: spent})
+	s.current++
+}
+
+// Actions - Get the actions played to reach the current state
+func (s *Session) Actions() []OthelloBoardGameAction {
+	actions := make([]OthelloBoardGameAction, s.current)
+	for i, move := range s.moves[:s.current] {
+		actions[i] = OthelloBoardGameAction{move: move.Move, value: move.Player}
+	}
+	return actions
+}
+
+// Record - Get the GameRecord of the moves played to reach the current state
+func (s *Session) Record() GameRecord {
+	record := NewRecord(s.states[0])
+	record.Moves = append(record.Moves, s.moves[:s.current]...)
+	record.Finish(s.State())
+	return record
+}
+
+// Undo - take back the last move, false when there is none
+func (s *Session) Undo() bool {
+	if s.current == 0 {
+		return false
+	}
+	s.current--
+	return true
+}
+
+// Redo - play the last move taken back again, false when there is none
+func (s *Session) Redo() bool {
+	if s.current == len(s.moves) {
+		return false
+	}
+	s.current++
+	return true
+}
+
+// UndoTo - take back moves until the last earlier state where player is to
+// move, false when there is none
+func (s *Session) UndoTo(player int) bool {
+	for i := s.current - 1; i >= 0; i-- {
+		if s.states[i].nextToMove == player {
+			s.current = i
+			return true
+		}
+	}
+	return false
+}
+
+// RedoTo - play moves taken back again until the next state where player is
+// to move, or all of them, false when there is none
+func (s *Session) RedoTo(player int) bool {
+	if s.current == len(s.moves) {
+		return false
+	}
+	s.current++
+	for s.current < len(s.moves) && s.State().nextToMove != player {
+		s.current++
+	}
+	return true
+}
@@ -0,0 +1,98 @@
+package othello
+
+import (
+	"reflect"
+	"testing"
+)
+
+// plays the moves in notation in session
+func playMoves(t *testing.T, session *Session, moves ...string) {
+	for _, notation := range moves {
+		move, err := ParseMove(notation)
+		if err != nil {
+			t.Fatal(err)
+		}
+		session.Play(NewAction(move, session.State().NextToMove()), 0)
+	}
+}
+
+func TestSessionUndoRedo(t *testing.T) {
+	session := NewSession(New(BLUE))
+	playMoves(t, session, "d3")
+	afterD3 := session.State()
+	playMoves(t, session, "c5")
+	afterC5 := session.State()
+
+	if !session.Undo() || !reflect.DeepEqual(session.State(), afterD3) {
+		t.Fatalf("undo should take back c5")
+	}
+
+	if !session.Redo() || !reflect.DeepEqual(session.State(), afterC5) {
+		t.Errorf("redo should play c5 again")
+	}
+
+	if session.Redo() {
+		t.Errorf("there should be nothing to redo")
+	}
+}
+
+func TestSessionUndoAll(t *testing.T) {
+	session := NewSession(New(BLUE))
+	playMoves(t, session, "d3", "c5", "f6")
+
+	for i := 0; i < 3; i++ {
+		session.Undo()
+	}
+
+	if !reflect.DeepEqual(session.State(), New(BLUE)) || len(session.Actions()) != 0 {
+		t.Errorf("undoing every move should go back to the start")
+	}
+}
+
+func TestSessionPlayForgetsRedo(t *testing.T) {
+	session := NewSession(New(BLUE))
+	playMoves(t, session, "d3", "c5")
+	session.Undo()
+	playMoves(t, session, "e3")
+
+	if session.Redo() {
+		t.Errorf("playing a move should forget the moves undone")
+	}
+
+	want := []string{"d3", "e3"}
+	for k, action := range session.Actions() {
+		if action.String() != want[k] {
+			t.Errorf("move %v should be %v but is %v", k, want[k], action)
+		}
+	}
+}
+
+func TestSessionUndoToPlayersTurn(t *testing.T) {
+	session := NewSession(New(BLUE))
+	playMoves(t, session, "d3", "c5", "f6", "f5")
+
+	if !session.UndoTo(BLUE) || len(session.Actions()) != 2 {
+		t.Errorf("undo should go back to blue's move f6, %v moves played", len(session.Actions()))
+	}
+
+	if !session.RedoTo(BLUE) || len(session.Actions()) != 4 {
+		t.Errorf("redo should go forward to blue's turn, %v moves played", len(session.Actions()))
+	}
+
+	session.UndoTo(BLUE)
+	session.UndoTo(BLUE)
+	if session.UndoTo(BLUE) {
+		t.Errorf("there should be no earlier turn of blue")
+	}
+}
+
+func TestSessionRecord(t *testing.T) {
+	session := NewSession(New(BLUE))
+	playMoves(t, session, "d3", "c5", "f6")
+	session.Undo()
+
+	state, err := session.Record().State()
+	if err != nil || !reflect.DeepEqual(state, session.State()) {
+		t.Errorf("record should replay to the current state but %v", err)
+	}
+}
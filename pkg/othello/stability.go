@@ -0,0 +1,94 @@
+package othello
+
+import (
+	"math/bits"
+)
+
+// A disc is stable when it can't be flipped for the rest of the game. That
+// holds when along each of the four lines through it, horizontal, vertical
+// and the two diagonals, the line is full or the disc has the edge of the
+// board or a stable disc of its own on one side. Corners are stable, and
+// stability spreads from them along the edges and into the board. The discs
+// found this way are a subset of the stable ones, but cover most of those
+// of real games. A disc is semi-stable when it isn't stable but the
+// opponent can't flip it with its next move.
+
+// Stability - Get the stable and semi-stable discs of player
+func (s OthelloBitboardGameState) Stability(player int) (stable, semiStable uint64) {
+	own, opp := s.blue, s.red
+	if player == RED {
+		own, opp = s.red, s.blue
+	}
+
+	stable = stableDiscs(own, opp)
+
+	var flippable uint64
+	for moves := bitboardMoves(opp, own); moves != 0; moves &= moves - 1 {
+		flippable |= bitboardFlips(opp, own, moves&-moves)
+	}
+
+	return stable, own &^ stable &^ flippable
+}
+
+// Stability - Get the stable and semi-stable discs of player
+func (s OthelloGameState) Stability(player int) (stable, semiStable uint64) {
+	return s.Bitboard().Stability(player)
+}
+
+// stableDiscs - stable discs of player, growing the discs protected along
+// every line from the edges until no more are found
+func stableDiscs(player, opp uint64) uint64 {
+	occupied := player | opp
+
+	// squares protected along each line without help of stable discs, on a
+	// full line or at the edge of the board
+	var protected [DIR / 2]uint64
+	for line := range protected {
+		d, e := 2*line, 2*line+1
+		edges := ^shiftBitboard(^uint64(0), d) | ^shiftBitboard(^uint64(0), e)
+		protected[line] = edges | filledTowards(occupied, d)&filledTowards(occupied, e)
+	}
+
+	var stable uint64
+	for {
+		next := player
+		for line := range protected {
+			d, e := 2*line, 2*line+1
+			next &= protected[line] | shiftBitboard(stable, d) | shiftBitboard(stable, e)
+		}
+		if next == stable {
+			return stable
+		}
+		stable = next
+	}
+}
+
+// filledTowards - squares of occupied from which every square up to the
+// edge of the board in direction dir is occupied too
+func filledTowards(occupied uint64, dir int) uint64 {
+	// the squares in direction dir of the edge have no neighbour there
+	back := dir ^ 1
+	edge := ^shiftBitboard(^uint64(0), back)
+
+	filled := occupied
+	for i := 0; i < BOARD_WIDTH-1; i++ {
+		filled &= edge | shiftBitboard(filled, back)
+	}
+	return filled
+}
+
+// stabilityHeuristic - stability heuristic of the evaluation function,
+// semi-stable discs counting half
+func stabilityHeuristic(s OthelloGameState) float64 {
+	b := s.Bitboard()
+	playerStable, playerSemiStable := b.Stability(s.nextToMove)
+	oppStable, oppSemiStable := b.Stability(opponent(s.nextToMove))
+
+	player := float64(bits.OnesCount64(playerStable)) + float64(bits.OnesCount64(playerSemiStable))/2
+	opp := float64(bits.OnesCount64(oppStable)) + float64(bits.OnesCount64(oppSemiStable))/2
+
+	if player+opp == 0 {
+		return 0
+	}
+	return 100 * (player - opp) / (player + opp)
+}
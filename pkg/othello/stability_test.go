@@ -0,0 +1,90 @@
+package othello
+
+import (
+	"math/bits"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestStability(t *testing.T) {
+	for _, test := range []struct {
+		position           string
+		player             int
+		stable, semiStable []string
+	}{
+		// nothing is stable in the initial position and RED can flip either
+		// of BLUE's discs
+		{"---------------------------OX------XO--------------------------- X", BLUE, nil, nil},
+		// stability spreads along the edge from the corner
+		{"XXXO------------------------------------------------------------ O", BLUE, []string{"a1", "b1", "c1"}, nil},
+		// d1 can be flipped from e1
+		{"XXXO------------------------------------------------------------ O", RED, nil, nil},
+		// RED has no discs to flip d1 with, but it isn't stable
+		{"---X------------------------------------------------------------ O", BLUE, nil, []string{"d1"}},
+		// the full row protects d1 along it, the edge along the others
+		{"OOOXOOOO-------------------------------------------------------- X", BLUE, []string{"d1"}, nil},
+	} {
+		state, err := ParsePosition(test.position)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		stable, semiStable := state.Stability(test.player)
+		if want := squares(t, test.stable); stable != want {
+			t.Errorf("stable discs of %v in %v should be %v but are %x", playerName(test.player), test.position, test.stable, stable)
+		}
+		if want := squares(t, test.semiStable); semiStable != want {
+			t.Errorf("semi-stable discs of %v in %v should be %v but are %x", playerName(test.player), test.position, test.semiStable, semiStable)
+		}
+	}
+}
+
+func TestStableDiscsStay(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 50; i++ {
+		state := randomPosition(rng, 10+rng.Intn(40))
+		blueStable, _ := state.Stability(BLUE)
+		redStable, _ := state.Stability(RED)
+
+		for !state.IsGameEnded() {
+			state = OthelloRandomRolloutPolicy(state, rng).ApplyTo(state).(OthelloBitboardGameState)
+			blue, red := state.Discs()
+			if blue&blueStable != blueStable || red&redStable != redStable {
+				t.Fatalf("stable discs were flipped reaching %v", state)
+			}
+		}
+	}
+}
+
+func TestFullBoardIsStable(t *testing.T) {
+	full, err := ParsePosition(strings.Repeat("XXOXOOOX", BOARD_WIDTH) + " X")
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := full.Bitboard()
+
+	blue, red := state.Discs()
+	blueStable, _ := state.Stability(BLUE)
+	redStable, _ := state.Stability(RED)
+	if blueStable != blue || redStable != red {
+		t.Errorf("every disc of the full board %v should be stable", state)
+	}
+	if bits.OnesCount64(blueStable|redStable) != PIECE_SLOTS {
+		t.Errorf("full board %v should have %v stable discs", state, PIECE_SLOTS)
+	}
+}
+
+// bitboard of the squares in notation
+func squares(t *testing.T, notation []string) uint64 {
+	var b uint64
+	for _, n := range notation {
+		move, err := ParseMove(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b |= squareBit(move)
+	}
+	return b
+}
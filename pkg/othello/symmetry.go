@@ -0,0 +1,117 @@
+package othello
+
+import (
+	"math/bits"
+)
+
+// The board has eight symmetries, the rotations and reflections of the
+// square. Symmetry t applies a reflection in the a1-h8 diagonal when t&4 is
+// set, then one swapping rows 1 and 8 when t&2 is set, then one swapping
+// columns a and h when t&1 is set. Symmetry 0 is the identity.
+
+// SYMMETRIES - number of symmetries of the board
+const SYMMETRIES = 8
+
+// Transform - Get the game state under symmetry t
+func (s OthelloGameState) Transform(t int) OthelloGameState {
+	return s.Bitboard().Transform(t).Mailbox()
+}
+
+// Transform - Get the game state under symmetry t
+func (s OthelloBitboardGameState) Transform(t int) OthelloBitboardGameState {
+	return OthelloBitboardGameState{nextToMove: s.nextToMove, blue: transform(t, s.blue), red: transform(t, s.red)}
+}
+
+// Canonical - Get the smallest of the symmetries of the game state, comparing
+// the bitboards of BLUE's and then RED's discs, and the symmetries taking
+// the game state to it, several when the game state is symmetric itself.
+// Symmetric positions have the same canonical form.
+func (s OthelloGameState) Canonical() (OthelloGameState, []int) {
+	canonical, symmetries := s.Bitboard().Canonical()
+	return canonical.Mailbox(), symmetries
+}
+
+// Canonical - Get the smallest of the symmetries of the game state and the
+// symmetries taking the game state to it, as for OthelloGameState
+func (s OthelloBitboardGameState) Canonical() (OthelloBitboardGameState, []int) {
+	best, symmetries := s, []int{0}
+	for t := 1; t < SYMMETRIES; t++ {
+		b := s.Transform(t)
+		if b == best {
+			symmetries = append(symmetries, t)
+		} else if b.blue < best.blue || b.blue == best.blue && b.red < best.red {
+			best, symmetries = b, []int{t}
+		}
+	}
+	return best, symmetries
+}
+
+// TransformMove - Get the square move is taken to by symmetry t, PASS for
+// PASS
+func TransformMove(t, move int) int {
+	if move == PASS {
+		return PASS
+	}
+	return bitSquare(bits.TrailingZeros64(transform(t, squareBit(move))))
+}
+
+// UntransformMove - Get the square symmetry t takes to move, PASS for PASS
+func UntransformMove(t, move int) int {
+	if move == PASS {
+		return PASS
+	}
+	return bitSquare(bits.TrailingZeros64(untransform(t, squareBit(move))))
+}
+
+// transform - bitboard b under symmetry t
+func transform(t int, b uint64) uint64 {
+	if t&4 != 0 {
+		b = transpose(b)
+	}
+	if t&2 != 0 {
+		b = bits.ReverseBytes64(b)
+	}
+	if t&1 != 0 {
+		b = mirror(b)
+	}
+	return b
+}
+
+// untransform - bitboard which symmetry t takes to b
+func untransform(t int, b uint64) uint64 {
+	if t&1 != 0 {
+		b = mirror(b)
+	}
+	if t&2 != 0 {
+		b = bits.ReverseBytes64(b)
+	}
+	if t&4 != 0 {
+		b = transpose(b)
+	}
+	return b
+}
+
+// swaps columns a and h, b and g and so on
+func mirror(b uint64) uint64 {
+	const k1 = 0x5555555555555555
+	const k2 = 0x3333333333333333
+	const k4 = 0x0f0f0f0f0f0f0f0f
+	b = ((b >> 1) & k1) | ((b & k1) << 1)
+	b = ((b >> 2) & k2) | ((b & k2) << 2)
+	b = ((b >> 4) & k4) | ((b & k4) << 4)
+	return b
+}
+
+// reflects in the a1-h8 diagonal, swapping columns and rows
+func transpose(b uint64) uint64 {
+	const k1 = 0x5500550055005500
+	const k2 = 0x3333000033330000
+	const k4 = 0x0f0f0f0f00000000
+	t := k4 & (b ^ (b << 28))
+	b ^= t ^ (t >> 28)
+	t = k2 & (b ^ (b << 14))
+	b ^= t ^ (t >> 14)
+	t = k1 & (b ^ (b << 7))
+	b ^= t ^ (t >> 7)
+	return b
+}
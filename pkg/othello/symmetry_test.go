@@ -0,0 +1,70 @@
+package othello
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestSymmetriesOfSquare(t *testing.T) {
+	// c2 is column 2 and row 1 counting from zero
+	want := map[int]string{0: "c2", 1: "f2", 2: "c7", 3: "f7", 4: "b3", 5: "g3", 6: "b6", 7: "g6"}
+	c2, _ := ParseMove("c2")
+
+	for symmetry, notation := range want {
+		if move := MoveString(TransformMove(symmetry, c2)); move != notation {
+			t.Errorf("symmetry %v should take c2 to %v but takes it to %v", symmetry, notation, move)
+		}
+		if UntransformMove(symmetry, TransformMove(symmetry, c2)) != c2 {
+			t.Errorf("symmetry %v should be undone", symmetry)
+		}
+	}
+
+	if TransformMove(3, PASS) != PASS {
+		t.Errorf("symmetries should leave passes alone")
+	}
+}
+
+func TestSymmetricPositionsAreCanonicallyEqual(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 20; i++ {
+		state := randomPosition(rng, 20+rng.Intn(30)).Mailbox()
+		canonical, symmetries := state.Canonical()
+
+		if state.Transform(symmetries[0]).String() != canonical.String() {
+			t.Errorf("symmetry %v should take %v to its canonical form", symmetries[0], state)
+		}
+
+		for symmetry := 0; symmetry < SYMMETRIES; symmetry++ {
+			other, _ := state.Transform(symmetry).Canonical()
+			if other.String() != canonical.String() || other.Hash() != canonical.Hash() {
+				t.Errorf("symmetry %v of %v should have the canonical form %v but has %v", symmetry, state, canonical, other)
+			}
+		}
+	}
+}
+
+func TestSymmetriesKeepLegalActions(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+
+	for i := 0; i < 20; i++ {
+		state := randomPosition(rng, 20+rng.Intn(30))
+		for symmetry := 0; symmetry < SYMMETRIES; symmetry++ {
+			transformed := state.Transform(symmetry)
+			moves := make(map[int]bool)
+			for _, action := range transformed.GetLegalActions() {
+				moves[action.(OthelloBoardGameAction).move] = true
+			}
+
+			actions := state.GetLegalActions()
+			if len(actions) != len(moves) {
+				t.Fatalf("symmetry %v of %v should have %v legal actions but has %v", symmetry, state, len(actions), len(moves))
+			}
+			for _, action := range actions {
+				if move := TransformMove(symmetry, action.(OthelloBoardGameAction).move); !moves[move] {
+					t.Errorf("symmetry %v of %v should have the legal move %v", symmetry, state, MoveString(move))
+				}
+			}
+		}
+	}
+}
@@ -2,6 +2,17 @@ package othello
 
 // evaluation function
 func evaluate(s OthelloGameState, parityWeight, mobilityWeight, cornersWeight, frontiersWeight float64) float64 {
+	parityHeuristic, mobilityHeuristic, cornersHeuristic, frontiersHeuristic := heuristics(s)
+
+	// final score
+	return parityWeight*parityHeuristic +
+		mobilityWeight*mobilityHeuristic +
+		cornersWeight*cornersHeuristic +
+		frontiersWeight*frontiersHeuristic
+}
+
+// parity, mobility, corners and frontiers heuristics of the evaluation function
+func heuristics(s OthelloGameState) (parity, mobility, corners, frontiers float64) {
 	nextToMove := s.nextToMove
 	board := s.board
 
@@ -93,29 +104,30 @@ func evaluate(s OthelloGameState, parityWeight, mobilityWeight, cornersWeight, f
 
 	cornersHeuristic := playerCorners - oppCorners
 
-	// final score
-	return parityWeight*parityHeuristic +
-		mobilityWeight*mobilityHeuristic +
-		cornersWeight*cornersHeuristic +
-		frontiersWeight*frontiersHeuristic
+	return parityHeuristic, mobilityHeuristic, cornersHeuristic, frontiersHeuristic
 }
 
-// makes a move
-func makeMove(move int, s OthelloGameState) {
+// makes a move, returning the change of the Zobrist hash of the squares
+func makeMove(move int, s OthelloGameState) uint64 {
 	nextToMove := s.nextToMove
 	board := s.board
 
 	board[move] = nextToMove
+	hash := zobristSquares[nextToMove][move]
 
 	for i := 0; i < DIR; i++ {
-		makeFlips(board, move, ALLDIRECTIONS[i], nextToMove)
+		hash ^= makeFlips(board, move, ALLDIRECTIONS[i], nextToMove)
 	}
+
+	return hash
 }
 
-// flips pieces bracketed by opponent's piece
-func makeFlips(board []int, move, dir, nextToMove int) {
+// flips pieces bracketed by opponent's piece, returning the change of the
+// Zobrist hash of the squares
+func makeFlips(board []int, move, dir, nextToMove int) uint64 {
 	var bracketer int
 	var c int
+	var hash uint64
 
 	bracketer = wouldFlip(board, move, dir, nextToMove)
 
@@ -123,12 +135,15 @@ func makeFlips(board []int, move, dir, nextToMove int) {
 		c = move + dir
 		for {
 			board[c] = nextToMove
+			hash ^= zobristSquares[nextToMove][c] ^ zobristSquares[opponent(nextToMove)][c]
 			c = c + dir
 			if c == bracketer {
 				break
 			}
 		}
 	}
+
+	return hash
 }
 
 // gets the number of legal actions for player
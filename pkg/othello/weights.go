@@ -0,0 +1,106 @@
+package othello
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Heuristic weights are stored as text, a line per heuristic:
+//
+//	parity 21.45
+//	mobility 3.37
+//	corners 70
+//	frontiers 5.38
+//	stability 0
+//
+// heuristics missing from the file weigh 0. Lines starting with # are
+// comments.
+
+// Weights - weights of the parity, mobility, corners, frontiers and
+// stability heuristics of the evaluation function
+type Weights struct {
+	Parity    float64
+	Mobility  float64
+	Corners   float64
+	Frontiers float64
+	// Stability - weight of the stable and semi-stable discs, which cost
+	// more to find than the other heuristics and are left out when 0
+	Stability float64
+}
+
+// MEDIUM_WEIGHTS - equally weighted heuristics of OthelloMediumRolloutPolicy
+var MEDIUM_WEIGHTS = Weights{Parity: 25.00, Mobility: 25.00, Corners: 25.00, Frontiers: 25.00}
+
+// HARD_WEIGHTS - heuristic weights of OthelloHardRolloutPolicy
+var HARD_WEIGHTS = Weights{Parity: 21.45, Mobility: 3.37, Corners: 70.00, Frontiers: 5.38}
+
+// HeuristicEvaluator - Evaluator weighing the heuristics of the evaluation
+// function with w
+func HeuristicEvaluator(w Weights) Evaluator {
+	return func(s OthelloGameState) float64 {
+		score := evaluate(s, w.Parity, w.Mobility, w.Corners, w.Frontiers)
+		if w.Stability != 0 {
+			score += w.Stability * stabilityHeuristic(s)
+		}
+		return score
+	}
+}
+
+// Heuristics - Get the values of the parity, mobility, corners, frontiers
+// and stability heuristics of the game state for the player to move, in the
+// fields of their weights, so the evaluation of weights w is their dot
+// product with w
+func Heuristics(s OthelloGameState) Weights {
+	parity, mobility, corners, frontiers := heuristics(s)
+	return Weights{Parity: parity, Mobility: mobility, Corners: corners, Frontiers: frontiers, Stability: stabilityHeuristic(s)}
+}
+
+// Save - write the weights in their text format
+func (w Weights) Save(wr io.Writer) error {
+	buf := bufio.NewWriter(wr)
+	for _, heuristic := range []struct {
+		name   string
+		weight float64
+	}{{"parity", w.Parity}, {"mobility", w.Mobility}, {"corners", w.Corners}, {"frontiers", w.Frontiers}, {"stability", w.Stability}} {
+		fmt.Fprintf(buf, "%s %s\n", heuristic.name, strconv.FormatFloat(heuristic.weight, 'g', -1, 64))
+	}
+	return buf.Flush()
+}
+
+// LoadWeights - read weights written by Save
+func LoadWeights(r io.Reader) (Weights, error) {
+	w := Weights{}
+	weights := map[string]*float64{"parity": &w.Parity, "mobility": &w.Mobility, "corners": &w.Corners, "frontiers": &w.Frontiers, "stability": &w.Stability}
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return Weights{}, fmt.Errorf("othello: weights line %v: expected heuristic and weight", n)
+		}
+
+		weight, ok := weights[fields[0]]
+		if !ok {
+			return Weights{}, fmt.Errorf("othello: weights line %v: unknown heuristic %q", n, fields[0])
+		}
+		value, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+			return Weights{}, fmt.Errorf("othello: weights line %v: invalid weight %q", n, fields[1])
+		}
+		*weight = value
+	}
+
+	if err := scanner.Err(); err != nil {
+		return Weights{}, err
+	}
+	return w, nil
+}
@@ -0,0 +1,47 @@
+package othello
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestWeightsSaveLoad(t *testing.T) {
+	var buf bytes.Buffer
+	if err := HARD_WEIGHTS.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := LoadWeights(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w != HARD_WEIGHTS {
+		t.Errorf("loaded weights should be %+v but are %+v", HARD_WEIGHTS, w)
+	}
+
+	for _, weights := range []string{"parity", "parity x", "edges 1"} {
+		if _, err := LoadWeights(strings.NewReader(weights)); err == nil {
+			t.Errorf("weights %q should be rejected", weights)
+		}
+	}
+}
+
+func TestHeuristicsWeighToEvaluation(t *testing.T) {
+	state, err := ParsePosition("XXO------------------------OX------XO--------------------------- O")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := Heuristics(state)
+	w := HARD_WEIGHTS
+	w.Stability = 12.5
+	score := w.Parity*h.Parity + w.Mobility*h.Mobility + w.Corners*h.Corners + w.Frontiers*h.Frontiers + w.Stability*h.Stability
+	if math.Abs(score-HeuristicEvaluator(w)(state)) > 1e-9 {
+		t.Errorf("heuristics weighed by %+v should score %v but score %v", w, HeuristicEvaluator(w)(state), score)
+	}
+	if h.Stability >= 0 {
+		t.Errorf("the player to move should have fewer stable discs but has stability %v", h.Stability)
+	}
+}
@@ -0,0 +1,236 @@
+package othello
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// WTHOR files, the archive of tournament games of the Fédération Française
+// d'Othello, start with a 16 byte header followed by fixed size records:
+// 68 bytes per game in .wtb files, 20 bytes per player name in .JOU files and
+// 26 bytes per tournament name in .TRN files. A game lists its moves as
+// mailbox squares, col + 10*row like OthelloGameState, with passes left out
+// and zeros after the last move.
+
+// sizes of WTHOR headers and records
+const (
+	WTHOR_HEADER_SIZE     = 16
+	WTHOR_GAME_SIZE       = 68
+	WTHOR_PLAYER_SIZE     = 20
+	WTHOR_TOURNAMENT_SIZE = 26
+)
+
+// wthorHeader - header of a WTHOR file
+type wthorHeader struct {
+	// Created - creation date of the file as year, month and day
+	Created [3]int
+	// Records - number of games of a .wtb file
+	Records int
+	// Names - number of names of a .JOU or .TRN file
+	Names int
+	// Year - year the games of a .wtb file were played
+	Year int
+	// BoardSize - 8, or 10 for the games of a 10x10 board
+	BoardSize int
+	// Solitaire - whether the games are solitaires
+	Solitaire bool
+	// Depth - depth at which the theoretical scores were computed
+	Depth int
+}
+
+// WthorGame - game of a .wtb file, its actions validated by replaying them
+// with passes put back in
+type WthorGame struct {
+	Tournament int
+	Black      int
+	White      int
+	Year       int
+	// BlackDiscs - black's final disc count, empty squares counting for the
+	// winner
+	BlackDiscs int
+	// TheoreticalBlackDiscs - black's disc count with perfect play from the
+	// depth of the header
+	TheoreticalBlackDiscs int
+	Actions               []OthelloBoardGameAction
+}
+
+// WthorReader - reads the games of a .wtb file one at a time
+type WthorReader struct {
+	r      io.Reader
+	header wthorHeader
+	read   int
+}
+
+// NewWthorReader - initializes a new WthorReader, reading the header of r
+func NewWthorReader(r io.Reader) (*WthorReader, error) {
+	header, err := readwthorHeader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if header.BoardSize != BOARD_WIDTH {
+		return nil, fmt.Errorf("othello: unsupported WTHOR board size %v", header.BoardSize)
+	}
+
+	return &WthorReader{r: r, header: header}, nil
+}
+
+// Next - read the next game, io.EOF once all the games of the header are read
+func (r *WthorReader) Next() (WthorGame, error) {
+	if r.read == r.header.Records {
+		return WthorGame{}, io.EOF
+	}
+
+	var record [WTHOR_GAME_SIZE]byte
+	if _, err := io.ReadFull(r.r, record[:]); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return WthorGame{}, err
+	}
+	r.read++
+
+	game := WthorGame{
+		Tournament:            int(binary.LittleEndian.Uint16(record[0:])),
+		Black:                 int(binary.LittleEndian.Uint16(record[2:])),
+		White:                 int(binary.LittleEndian.Uint16(record[4:])),
+		Year:                  r.header.Year,
+		BlackDiscs:            int(record[6]),
+		TheoreticalBlackDiscs: int(record[7]),
+	}
+
+	actions, err := wthorActions(record[8:])
+	if err != nil {
+		return WthorGame{}, fmt.Errorf("othello: WTHOR game %v: %v", r.read, err)
+	}
+	game.Actions = actions
+
+	return game, nil
+}
+
+// Record - Get the GameRecord of the game, naming its players and tournament
+// from the names of the .JOU and .TRN files when known
+func (g WthorGame) Record(players, tournaments []string) GameRecord {
+	record := NewRecord(New(BLUE))
+	record.Black = wthorName(players, g.Black)
+	record.White = wthorName(players, g.White)
+	record.Place = wthorName(tournaments, g.Tournament)
+	record.Date = strconv.Itoa(g.Year)
+
+	for _, action := range g.Actions {
+		record.Moves = append(record.Moves, RecordMove{Move: action.move, Player: action.value})
+	}
+
+	if diff := 2*g.BlackDiscs - PIECE_SLOTS; diff == 0 {
+		record.Result = "0.000"
+	} else {
+		record.Result = fmt.Sprintf("%+.3f", float64(diff))
+	}
+
+	return record
+}
+
+// ReadWthorPlayers - read the player names of a .JOU file
+func ReadWthorPlayers(r io.Reader) ([]string, error) {
+	return readWthorNames(r, WTHOR_PLAYER_SIZE)
+}
+
+// ReadWthorTournaments - read the tournament names of a .TRN file
+func ReadWthorTournaments(r io.Reader) ([]string, error) {
+	return readWthorNames(r, WTHOR_TOURNAMENT_SIZE)
+}
+
+// reads and decodes a header
+func readwthorHeader(r io.Reader) (wthorHeader, error) {
+	var header [WTHOR_HEADER_SIZE]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return wthorHeader{}, fmt.Errorf("othello: reading WTHOR header: %v", err)
+	}
+
+	boardSize := int(header[12])
+	if boardSize == 0 {
+		// older files leave the board size out
+		boardSize = BOARD_WIDTH
+	}
+
+	return wthorHeader{
+		Created:   [3]int{100*int(header[0]) + int(header[1]), int(header[2]), int(header[3])},
+		Records:   int(binary.LittleEndian.Uint32(header[4:])),
+		Names:     int(binary.LittleEndian.Uint16(header[8:])),
+		Year:      int(binary.LittleEndian.Uint16(header[10:])),
+		BoardSize: boardSize,
+		Solitaire: header[13] == 1,
+		Depth:     int(header[14]),
+	}, nil
+}
+
+// reads the names of a .JOU or .TRN file with records of size bytes
+func readWthorNames(r io.Reader, size int) ([]string, error) {
+	header, err := readwthorHeader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, header.Names)
+	record := make([]byte, size)
+	for i := range names {
+		if _, err := io.ReadFull(r, record); err != nil {
+			return nil, fmt.Errorf("othello: reading WTHOR name %v: %v", i+1, err)
+		}
+		names[i] = latin1(record)
+	}
+	return names, nil
+}
+
+// replays the moves of a game, putting back the passes left out
+func wthorActions(moves []byte) ([]OthelloBoardGameAction, error) {
+	state := New(BLUE)
+	actions := make([]OthelloBoardGameAction, 0, len(moves))
+
+	for i, move := range moves {
+		if move == 0 {
+			break
+		}
+
+		if state.IsGameEnded() {
+			return nil, fmt.Errorf("move %v after the end of the game", i+1)
+		}
+
+		if pass := (OthelloBoardGameAction{move: PASS, value: state.nextToMove}); IsLegalAction(state, pass) {
+			actions = append(actions, pass)
+			state = pass.ApplyTo(state).(OthelloGameState)
+		}
+
+		action := OthelloBoardGameAction{move: int(move), value: state.nextToMove}
+		if !IsLegalAction(state, action) {
+			return nil, fmt.Errorf("illegal move %v %v at move %v", playerName(action.value), action, i+1)
+		}
+		actions = append(actions, action)
+		state = action.ApplyTo(state).(OthelloGameState)
+	}
+
+	return actions, nil
+}
+
+// name of index in names, the index itself when names is unknown
+func wthorName(names []string, index int) string {
+	if index < len(names) {
+		return names[index]
+	}
+	return strconv.Itoa(index)
+}
+
+// decodes a zero terminated Latin-1 name
+func latin1(b []byte) string {
+	var name strings.Builder
+	for _, c := range b {
+		if c == 0 {
+			break
+		}
+		name.WriteRune(rune(c))
+	}
+	return strings.TrimSpace(name.String())
+}
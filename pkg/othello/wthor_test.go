@@ -0,0 +1,143 @@
+package othello
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// encodes games in a .wtb file, leaving out their passes
+func wthorFile(year int, games [][]OthelloBoardGameAction) []byte {
+	var b bytes.Buffer
+	header := make([]byte, WTHOR_HEADER_SIZE)
+	binary.LittleEndian.PutUint32(header[4:], uint32(len(games)))
+	binary.LittleEndian.PutUint16(header[10:], uint16(year))
+	header[12] = BOARD_WIDTH
+	b.Write(header)
+
+	for i, game := range games {
+		record := make([]byte, WTHOR_GAME_SIZE)
+		binary.LittleEndian.PutUint16(record[2:], uint16(i))
+		binary.LittleEndian.PutUint16(record[4:], uint16(i+1))
+		record[6] = 32
+		moves := record[8:8]
+		for _, action := range game {
+			if !action.IsPass() {
+				moves = append(moves, byte(action.move))
+			}
+		}
+		b.Write(record)
+	}
+	return b.Bytes()
+}
+
+// plays a random game to the end
+func randomGame(rng *rand.Rand) []OthelloBoardGameAction {
+	state := New(BLUE)
+	game := make([]OthelloBoardGameAction, 0)
+	for !state.IsGameEnded() {
+		actions := state.GetLegalActions()
+		action := actions[rng.Intn(len(actions))].(OthelloBoardGameAction)
+		game = append(game, action)
+		state = action.ApplyTo(state).(OthelloGameState)
+	}
+	return game
+}
+
+func TestWthorReaderPutsBackPasses(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	games := make([][]OthelloBoardGameAction, 100)
+	passes := 0
+	for i := range games {
+		games[i] = randomGame(rng)
+		for _, action := range games[i] {
+			if action.IsPass() {
+				passes++
+			}
+		}
+	}
+	if passes == 0 {
+		t.Fatalf("random games should include passes")
+	}
+
+	reader, err := NewWthorReader(bytes.NewReader(wthorFile(2001, games)))
+	if err != nil {
+		t.Fatalf("header should be read but %v", err)
+	}
+
+	for i, want := range games {
+		game, err := reader.Next()
+		if err != nil {
+			t.Fatalf("game %v should be read but %v", i, err)
+		}
+
+		if !reflect.DeepEqual(game.Actions, want) {
+			t.Errorf("game %v should be %v but is %v", i, want, game.Actions)
+		}
+
+		if game.Black != i || game.White != i+1 || game.Year != 2001 {
+			t.Errorf("game %v should be between %v and %v in 2001 but is %+v", i, i, i+1, game)
+		}
+	}
+
+	if _, err := reader.Next(); err != io.EOF {
+		t.Errorf("reader should be done but %v", err)
+	}
+}
+
+func TestWthorRecord(t *testing.T) {
+	game := randomGame(rand.New(rand.NewSource(2)))
+	reader, _ := NewWthorReader(bytes.NewReader(wthorFile(1999, [][]OthelloBoardGameAction{game})))
+	wthor, _ := reader.Next()
+
+	record := wthor.Record([]string{"alice", "bob"}, []string{"Paris"})
+	if record.Black != "alice" || record.White != "bob" || record.Place != "Paris" || record.Date != "1999" || record.Result != "0.000" {
+		t.Errorf("record should name the players, tournament, year and result but is %+v", record)
+	}
+
+	if _, err := record.State(); err != nil {
+		t.Errorf("record should replay but %v", err)
+	}
+}
+
+func TestWthorIllegalMove(t *testing.T) {
+	file := wthorFile(2001, [][]OthelloBoardGameAction{{{move: 11, value: BLUE}}})
+	reader, _ := NewWthorReader(bytes.NewReader(file))
+
+	if _, err := reader.Next(); err == nil {
+		t.Errorf("a1 should not be a legal first move")
+	}
+}
+
+func TestWthorTruncatedFile(t *testing.T) {
+	file := wthorFile(2001, [][]OthelloBoardGameAction{randomGame(rand.New(rand.NewSource(3)))})
+	reader, _ := NewWthorReader(bytes.NewReader(file[:len(file)-1]))
+
+	if _, err := reader.Next(); err != io.ErrUnexpectedEOF {
+		t.Errorf("truncated game should not be read but %v", err)
+	}
+}
+
+func TestReadWthorPlayers(t *testing.T) {
+	var b bytes.Buffer
+	header := make([]byte, WTHOR_HEADER_SIZE)
+	binary.LittleEndian.PutUint16(header[8:], 2)
+	b.Write(header)
+	for _, name := range []string{"Tastet Marc", "L\xe9vy Ren\xe9"} {
+		record := make([]byte, WTHOR_PLAYER_SIZE)
+		copy(record, name)
+		b.Write(record)
+	}
+
+	players, err := ReadWthorPlayers(&b)
+	if err != nil {
+		t.Fatalf("players should be read but %v", err)
+	}
+
+	if !reflect.DeepEqual(players, []string{"Tastet Marc", "Lévy René"}) {
+		t.Errorf("players read wrong, %v", players)
+	}
+}
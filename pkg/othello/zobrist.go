@@ -0,0 +1,70 @@
+package othello
+
+import (
+	"math/bits"
+)
+
+// Zobrist hashing keeps a random key per piece and square and one for RED to
+// move, the hash of a state is the xor of the keys of its discs and side to
+// move. ApplyTo only xors in the keys of the squares that changed, bitboard
+// states hash the same from their discs.
+
+// seed of the Zobrist keys, fixed so hashes are the same across runs
+const zobristSeed = 0x0123456789abcdef
+
+// zobristSquares - key of each piece on each mailbox square, zero for EMPTY
+var zobristSquares [3][BOARD_SIZE]uint64
+
+// zobristSide - key of RED being the player to move
+var zobristSide uint64
+
+func init() {
+	state := uint64(zobristSeed)
+	for _, piece := range []int{BLUE, RED} {
+		for i := FIRST_BLOCK; i <= LAST_BLOCK; i++ {
+			if bound(i) {
+				zobristSquares[piece][i] = splitmix64(&state)
+			}
+		}
+	}
+	zobristSide = splitmix64(&state)
+}
+
+// zobristHash - Zobrist hash of board with nextToMove to play
+func zobristHash(board []int, nextToMove int) uint64 {
+	var hash uint64
+	for i := FIRST_BLOCK; i <= LAST_BLOCK; i++ {
+		if bound(i) {
+			hash ^= zobristSquares[board[i]][i]
+		}
+	}
+	if nextToMove == RED {
+		hash ^= zobristSide
+	}
+	return hash
+}
+
+// zobristBitboardHash - Zobrist hash of the bitboards of BLUE's and RED's
+// discs with nextToMove to play
+func zobristBitboardHash(blue, red uint64, nextToMove int) uint64 {
+	var hash uint64
+	for ; blue != 0; blue &= blue - 1 {
+		hash ^= zobristSquares[BLUE][bitSquare(bits.TrailingZeros64(blue))]
+	}
+	for ; red != 0; red &= red - 1 {
+		hash ^= zobristSquares[RED][bitSquare(bits.TrailingZeros64(red))]
+	}
+	if nextToMove == RED {
+		hash ^= zobristSide
+	}
+	return hash
+}
+
+// splitmix64 - next number of the SplitMix64 generator of state
+func splitmix64(state *uint64) uint64 {
+	*state += 0x9e3779b97f4a7c15
+	z := *state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
@@ -0,0 +1,56 @@
+package othello
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestZobristHashIncremental(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for game := 0; game < 20; game++ {
+		state := New(BLUE)
+		for !state.IsGameEnded() {
+			actions := state.GetLegalActions()
+			state = actions[rng.Intn(len(actions))].ApplyTo(state).(OthelloGameState)
+
+			if want := zobristHash(state.board, state.nextToMove); state.Hash() != want {
+				t.Fatalf("hash of %v is %x but should be %x", state, state.Hash(), want)
+			}
+		}
+	}
+}
+
+func TestZobristHashOfConvertedStates(t *testing.T) {
+	state := New(BLUE)
+	action := OthelloBoardGameAction{move: 34, value: BLUE}
+	next := action.ApplyTo(state).(OthelloGameState)
+
+	if next.Bitboard().Mailbox().Hash() != next.Hash() {
+		t.Errorf("state converted to a bitboard and back should hash the same")
+	}
+
+	parsed, err := ParsePosition(next.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Hash() != next.Hash() {
+		t.Errorf("parsed state should hash the same")
+	}
+}
+
+func TestZobristHashOfBitboards(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for game := 0; game < 20; game++ {
+		state := New(BLUE)
+		for !state.IsGameEnded() {
+			actions := state.GetLegalActions()
+			state = actions[rng.Intn(len(actions))].ApplyTo(state).(OthelloGameState)
+
+			if b := state.Bitboard(); b.Hash() != state.Hash() {
+				t.Fatalf("bitboard of %v hashes to %x but should hash to %x", state, b.Hash(), state.Hash())
+			}
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package tune
+
+import (
+	"math/rand"
+
+	"github.com/unathi-skosana/gothello/pkg/gomcts"
+	"github.com/unathi-skosana/gothello/pkg/othello"
+)
+
+// Sample - position of a game labeled with how the game ended for the player
+// to move
+type Sample struct {
+	State othello.OthelloBitboardGameState
+	// Result - 1 when the player to move won the game, 0.5 for a draw and 0
+	// when it lost
+	Result float64
+}
+
+// RecordSamples - positions of a game record before the end of the game,
+// which must be over or have a result
+func RecordSamples(record othello.GameRecord) ([]Sample, error) {
+	actions, err := record.Actions()
+	if err != nil {
+		return nil, err
+	}
+
+	winner, err := record.Winner()
+	if err != nil {
+		return nil, err
+	}
+
+	samples := make([]Sample, 0, len(actions))
+	var state gomcts.GameState = record.Start.Bitboard()
+	for _, action := range actions {
+		samples = append(samples, newSample(state, winner))
+		state = action.ApplyTo(state)
+	}
+	return samples, nil
+}
+
+// SelfPlay - positions of games from the initial position with player taking
+// both sides after randomPlies random moves, so the games differ
+func SelfPlay(games, randomPlies int, player gomcts.Player, rng *rand.Rand) []Sample {
+	samples := make([]Sample, 0, games*othello.PIECE_SLOTS)
+
+	for g := 0; g < games; g++ {
+		var state gomcts.GameState = othello.NewBitboard(othello.BLUE)
+		played := make([]gomcts.GameState, 0, othello.PIECE_SLOTS)
+
+		for ply := 0; !state.IsGameEnded(); ply++ {
+			played = append(played, state)
+			if ply < randomPlies {
+				state = othello.OthelloRandomRolloutPolicy(state, rng).ApplyTo(state)
+			} else {
+				state = player(state).ApplyTo(state)
+			}
+		}
+
+		winner, _ := state.EvaluateGame()
+		for _, s := range played {
+			samples = append(samples, newSample(s, winner))
+		}
+	}
+
+	return samples
+}
+
+// newSample - sample of state in a game won by winner
+func newSample(state gomcts.GameState, winner gomcts.GameResult) Sample {
+	sample := Sample{State: state.(othello.OthelloBitboardGameState)}
+	if winner == gomcts.GameResult(state.NextToMove()) {
+		sample.Result = 1
+	} else if winner == gomcts.GameResult(othello.EMPTY) {
+		sample.Result = 0.5
+	}
+	return sample
+}
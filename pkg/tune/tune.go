@@ -0,0 +1,194 @@
+package tune
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/unathi-skosana/gothello/pkg/othello"
+)
+
+// Weights are fit Texel style: the evaluation of a position divided by Scale
+// is taken as the logit of the player to move winning, and the weights
+// minimizing the cross entropy of that prediction and the results of the
+// samples are found by stochastic gradient descent with AdaGrad steps.
+
+// EPOCHS - passes over the samples by default
+const EPOCHS = 20
+
+// LEARNING_RATE - AdaGrad step size by default
+const LEARNING_RATE = 0.1
+
+// SCALE - evaluation per unit of the logit of winning by default
+const SCALE = 100
+
+// Options - settings of a fit
+type Options struct {
+	// Epochs - passes over the samples, EPOCHS when 0
+	Epochs int
+	// LearningRate - AdaGrad step size, LEARNING_RATE when 0
+	LearningRate float64
+	// Regularization - L2 penalty on the weights, keeping those of rare
+	// features small
+	Regularization float64
+	// Scale - evaluation per unit of the logit of winning, SCALE when 0
+	Scale float64
+	// Seed - seed of the order the samples are visited in
+	Seed int64
+}
+
+// FitHeuristics - weights of the heuristics of the evaluation function
+// predicting the results of samples best, and the mean cross entropy of
+// their predictions
+func FitHeuristics(samples []Sample, options Options) (othello.Weights, float64) {
+	examples := make([]example, len(samples))
+	for i, sample := range samples {
+		h := othello.Heuristics(sample.State.Mailbox())
+		examples[i] = example{
+			features: []int32{0, 1, 2, 3, 4},
+			values:   []float64{h.Parity, h.Mobility, h.Corners, h.Frontiers, h.Stability},
+			result:   sample.Result,
+		}
+	}
+
+	w, loss := fit(examples, 5, options)
+	return othello.Weights{Parity: w[0], Mobility: w[1], Corners: w[2], Frontiers: w[3], Stability: w[4]}, loss
+}
+
+// FitPatterns - pattern weights predicting the results of samples best, and
+// the mean cross entropy of their predictions
+func FitPatterns(samples []Sample, options Options) (*othello.PatternWeights, float64) {
+	// offset of the weights of each pattern in each phase
+	var offsets [othello.PATTERN_PHASES][]int32
+	n := 0
+	for phase := range offsets {
+		offsets[phase] = make([]int32, len(othello.PATTERNS))
+		for p, pattern := range othello.PATTERNS {
+			offsets[phase][p] = int32(n)
+			n += pattern.Size()
+		}
+	}
+
+	examples := make([]example, len(samples))
+	for i, sample := range samples {
+		s := sample.State.Mailbox()
+		phase := othello.PatternPhase(s)
+		features := make([]int32, 0)
+		for p, pattern := range othello.PATTERNS {
+			for _, instance := range pattern.Instances {
+				features = append(features, offsets[phase][p]+int32(othello.PatternIndex(s, instance)))
+			}
+		}
+		examples[i] = example{features: features, result: sample.Result}
+	}
+
+	w, loss := fit(examples, n, options)
+
+	weights := othello.NewPatternWeights()
+	for phase := range offsets {
+		for p, pattern := range othello.PATTERNS {
+			for index := 0; index < pattern.Size(); index++ {
+				weights.SetWeight(phase, p, index, w[int(offsets[phase][p])+index])
+			}
+		}
+	}
+	return weights, loss
+}
+
+// example - features of a sample and its result
+type example struct {
+	features []int32
+	// values - values of the features, nil when they're all 1
+	values []float64
+	result float64
+}
+
+// value - value of the i-th feature of e
+func (e example) value(i int) float64 {
+	if e.values == nil {
+		return 1
+	}
+	return e.values[i]
+}
+
+// fit - logistic regression of the results of examples on their n features,
+// the weights in units of the evaluation and the mean cross entropy
+func fit(examples []example, n int, options Options) ([]float64, float64) {
+	epochs := options.Epochs
+	if epochs <= 0 {
+		epochs = EPOCHS
+	}
+	rate := options.LearningRate
+	if rate <= 0 {
+		rate = LEARNING_RATE
+	}
+	scale := options.Scale
+	if scale <= 0 {
+		scale = SCALE
+	}
+
+	// features are divided by their largest value, so one step size suits
+	// heuristics in the hundreds and patterns that are there or not
+	largest := make([]float64, n)
+	for _, e := range examples {
+		for i, f := range e.features {
+			largest[f] = math.Max(largest[f], math.Abs(e.value(i)))
+		}
+	}
+	for f := range largest {
+		if largest[f] == 0 {
+			largest[f] = 1
+		}
+	}
+
+	theta := make([]float64, n)
+	squares := make([]float64, n)
+	rng := rand.New(rand.NewSource(options.Seed))
+	order := rng.Perm(len(examples))
+
+	for epoch := 0; epoch < epochs; epoch++ {
+		rng.Shuffle(len(order), func(i, j int) { order[i], order[j] = order[j], order[i] })
+
+		for _, k := range order {
+			e := examples[k]
+			z := 0.0
+			for i, f := range e.features {
+				z += theta[f] * e.value(i) / largest[f]
+			}
+
+			g := sigmoid(z) - e.result
+			for i, f := range e.features {
+				grad := g*e.value(i)/largest[f] + options.Regularization*theta[f]
+				squares[f] += grad * grad
+				theta[f] -= rate * grad / math.Sqrt(squares[f]+1e-8)
+			}
+		}
+	}
+
+	// back to the units of the features
+	for f := range theta {
+		theta[f] /= largest[f]
+	}
+
+	loss := 0.0
+	for _, e := range examples {
+		z := 0.0
+		for i, f := range e.features {
+			z += theta[f] * e.value(i)
+		}
+		p := math.Min(math.Max(sigmoid(z), 1e-15), 1-1e-15)
+		loss -= e.result*math.Log(p) + (1-e.result)*math.Log(1-p)
+	}
+	if len(examples) > 0 {
+		loss /= float64(len(examples))
+	}
+
+	for f := range theta {
+		theta[f] *= scale
+	}
+	return theta, loss
+}
+
+// logistic function
+func sigmoid(z float64) float64 {
+	return 1 / (1 + math.Exp(-z))
+}
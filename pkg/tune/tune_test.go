@@ -0,0 +1,124 @@
+package tune
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/unathi-skosana/gothello/pkg/gomcts"
+	"github.com/unathi-skosana/gothello/pkg/othello"
+)
+
+// random positions of random games, labeled by label
+func labeledSamples(n int, label func(s othello.OthelloGameState) float64) []Sample {
+	rng := rand.New(rand.NewSource(1))
+	random := func(state gomcts.GameState) gomcts.Action {
+		return othello.OthelloRandomRolloutPolicy(state, rng)
+	}
+	samples := SelfPlay(n/othello.PIECE_SLOTS+1, 0, random, rng)
+	for i := range samples {
+		samples[i].Result = label(samples[i].State.Mailbox())
+	}
+	return samples
+}
+
+func TestRecordSamples(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	var state gomcts.GameState = othello.New(othello.BLUE)
+	record := othello.NewRecord(state.(othello.OthelloGameState))
+	for !state.IsGameEnded() {
+		action := othello.OthelloRandomRolloutPolicy(state, rng).(othello.OthelloBoardGameAction)
+		record.Moves = append(record.Moves, othello.RecordMove{Move: action.GetMove(), Player: action.GetValue()})
+		state = action.ApplyTo(state)
+	}
+
+	samples, err := RecordSamples(record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(samples) != len(record.Moves) {
+		t.Fatalf("record of %v moves should give as many samples but gives %v", len(record.Moves), len(samples))
+	}
+
+	winner, _ := state.EvaluateGame()
+	for _, sample := range samples {
+		want := 0.0
+		if winner == gomcts.GameResult(othello.EMPTY) {
+			want = 0.5
+		} else if winner == gomcts.GameResult(sample.State.NextToMove()) {
+			want = 1
+		}
+		if sample.Result != want {
+			t.Errorf("sample %v should have result %v but has %v", sample.State, want, sample.Result)
+		}
+	}
+
+	if _, err := RecordSamples(othello.NewRecord(othello.New(othello.BLUE))); err == nil {
+		t.Errorf("record without a result should be rejected")
+	}
+}
+
+func TestSelfPlay(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	plays := 0
+	samples := SelfPlay(3, 4, func(state gomcts.GameState) gomcts.Action {
+		plays++
+		return state.GetLegalActions()[0]
+	}, rng)
+
+	if len(samples) != 4*3+plays {
+		t.Errorf("games should give a sample per ply, %v random and %v played, but give %v", 4*3, plays, len(samples))
+	}
+	for _, sample := range samples {
+		if sample.State.IsGameEnded() {
+			t.Errorf("sample %v is the end of a game", sample.State)
+		}
+	}
+}
+
+func TestFitHeuristics(t *testing.T) {
+	// the player to move wins with more discs
+	samples := labeledSamples(2000, func(s othello.OthelloGameState) float64 {
+		if p := othello.Heuristics(s).Parity; p > 0 {
+			return 1
+		} else if p == 0 {
+			return 0.5
+		}
+		return 0
+	})
+
+	w, loss := FitHeuristics(samples, Options{Seed: 1})
+	if w.Parity <= 0 || w.Parity < math.Abs(w.Mobility) || w.Parity < math.Abs(w.Frontiers) || w.Parity < math.Abs(w.Stability) {
+		t.Errorf("parity should weigh most but weights are %+v", w)
+	}
+	if loss >= math.Ln2 {
+		t.Errorf("fit should predict better than a coin but has loss %v", loss)
+	}
+}
+
+func TestFitPatterns(t *testing.T) {
+	// the player to move wins holding the center square d4
+	samples := labeledSamples(2000, func(s othello.OthelloGameState) float64 {
+		if s.GetBoard()[44] == s.NextToMove() {
+			return 1
+		}
+		return 0
+	})
+
+	w, loss := FitPatterns(samples, Options{Seed: 1})
+	if loss >= math.Ln2 {
+		t.Errorf("fit should predict better than a coin but has loss %v", loss)
+	}
+
+	var won, lost float64
+	for _, sample := range samples {
+		if sample.Result == 1 {
+			won += w.Evaluate(sample.State.Mailbox())
+		} else {
+			lost += w.Evaluate(sample.State.Mailbox())
+		}
+	}
+	if won <= 0 || lost >= 0 {
+		t.Errorf("won positions should evaluate above 0 and lost ones below, but total %v and %v", won, lost)
+	}
+}